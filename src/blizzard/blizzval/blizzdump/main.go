@@ -0,0 +1,222 @@
+// blizzdump prints the blizzval values stored in a file within an mpq
+// archive, such as replay.details.
+//
+//	blizzdump [flags] <archive> <file>
+//	blizzdump -raw [flags] <file>
+//
+// The file is decoded as a sequence of values until its end.  With
+// -tracker, each value is instead preceded by the game loop delta and
+// event type framing of replay.tracker.events.
+//
+// Values are decoded without their schema, so a choice of a null
+// variant, which is encoded as nothing, is misread; see
+// blizzval.Decode.  The tracker events, details and header of the
+// supported builds hold no choices.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"blizzard/blizzval"
+	"blizzard/mpq"
+	"blizzard/replay"
+)
+
+var (
+	raw      = flag.Bool("raw", false, "read a plain file rather than one within an archive")
+	tracker  = flag.Bool("tracker", false, "decode tracker event framing")
+	asJSON   = flag.Bool("json", false, "print values as JSON, one per line")
+	hints    = flag.Bool("hints", false, "with -json, mark u8, u32 and binary values")
+	hex      = flag.Bool("hex", false, "print integers in hexadecimal")
+	hexBlobs = flag.Bool("hexblobs", false, "print blobs as hex")
+	maxBlob  = flag.Int("maxblob", 0, "truncate blobs longer than this")
+	maxDepth = flag.Int("depth", 0, "elide values nested deeper than this")
+	compact  = flag.Bool("compact", false, "print each value on one line")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: blizzdump [flags] <archive> <file>\n")
+	fmt.Fprintf(os.Stderr, "       blizzdump -raw [flags] <file>\n")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func open(args []string) (io.ReadCloser, error) {
+	if *raw {
+		if len(args) != 1 {
+			usage()
+		}
+		return os.Open(args[0])
+	}
+	if len(args) != 2 {
+		usage()
+	}
+	r, err := mpq.OpenReader(args[0])
+	if err != nil {
+		return nil, err
+	}
+	f, err := r.OpenFile(args[1])
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{f, r}, nil
+}
+
+// readTrackerFraming reads the framing that precedes each tracker
+// event: a choice of game loop delta, holding a varint, followed by
+// the varint event type.
+func readTrackerFraming(r *bufio.Reader) (delta, typ int64, err error) {
+	expect := func(tag byte) error {
+		b, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != tag {
+			return fmt.Errorf("unexpected tag %#x in event framing, expected %#x", b, tag)
+		}
+		return nil
+	}
+
+	if err := expect(0x3); err != nil {
+		return 0, 0, err // io.EOF at the end of the stream
+	}
+	_, err = blizzval.DecodeVarInt(r)
+	if err == nil {
+		err = expect(0x9)
+	}
+	if err == nil {
+		delta, err = blizzval.DecodeVarInt(r)
+	}
+	if err == nil {
+		err = expect(0x9)
+	}
+	if err == nil {
+		typ, err = blizzval.DecodeVarInt(r)
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return delta, typ, nil
+}
+
+type printer struct {
+	w    *bufio.Writer
+	text *blizzval.PrintOptions
+	json *blizzval.JSONOptions
+}
+
+func (p *printer) value(v blizzval.Value) error {
+	if p.json != nil {
+		enc, err := blizzval.ToJSON(v, p.json)
+		if err != nil {
+			return err
+		}
+		p.w.Write(enc)
+	} else if err := blizzval.Fprint(p.w, v, p.text); err != nil {
+		return err
+	}
+	return p.w.WriteByte('\n')
+}
+
+func (p *printer) event(gameLoop int64, typ replay.TrackerEventType, v blizzval.Value) error {
+	if p.json == nil {
+		fmt.Fprintf(p.w, "%d %s ", gameLoop, typ)
+		return p.value(v)
+	}
+	enc, err := blizzval.ToJSON(v, p.json)
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(struct {
+		GameLoop int64           `json:"gameloop"`
+		Type     string          `json:"type"`
+		Event    json.RawMessage `json:"event"`
+	}{gameLoop, typ.String(), enc})
+	if err != nil {
+		return err
+	}
+	p.w.Write(line)
+	return p.w.WriteByte('\n')
+}
+
+func dump(r *bufio.Reader, p *printer) error {
+	var gameLoop int64
+	for {
+		if *tracker {
+			delta, typ, err := readTrackerFraming(r)
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			gameLoop += delta
+			v, err := blizzval.Decode(r)
+			if err == io.EOF {
+				return io.ErrUnexpectedEOF
+			} else if err != nil {
+				return err
+			}
+			if err := p.event(gameLoop, replay.TrackerEventType(typ), v); err != nil {
+				return err
+			}
+			continue
+		}
+
+		v, err := blizzval.Decode(r)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := p.value(v); err != nil {
+			return err
+		}
+	}
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	f, err := open(flag.Args())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	p := &printer{w: bufio.NewWriter(os.Stdout)}
+	if *asJSON {
+		p.json = &blizzval.JSONOptions{TypeHints: *hints}
+	} else {
+		p.text = &blizzval.PrintOptions{
+			Hex:        *hex,
+			MaxBlobLen: *maxBlob,
+			MaxDepth:   *maxDepth,
+			Compact:    *compact,
+		}
+		if *hexBlobs {
+			p.text.Blobs = blizzval.BlobHex
+		}
+	}
+
+	err = dump(bufio.NewReader(f), p)
+	if ferr := p.w.Flush(); err == nil {
+		err = ferr
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,80 @@
+// blizzschema infers the structure of a blizzval stream across many
+// archives, such as replay.details in a directory of replays.
+//
+//	blizzschema [-struct Name] <file> <archive>...
+//
+// It prints every key path with the wire types, presence, value ranges
+// and example values observed, or with -struct a draft Go struct for
+// blizzval/gen.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"blizzard/blizzval"
+	"blizzard/blizzval/schema"
+	"blizzard/mpq"
+)
+
+var structName = flag.String("struct", "", "print a draft Go struct with this name")
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: blizzschema [flags] <file> <archive>...\n")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+// addArchive adds the values of the named file in an archive to s.
+func addArchive(s *schema.Schema, path, name string) error {
+	r, err := mpq.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	f, err := r.OpenFile(name)
+	if err != nil {
+		return err
+	}
+	br := bufio.NewReader(f)
+	for {
+		v, err := blizzval.Decode(br)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		s.Add(v)
+	}
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() < 2 {
+		usage()
+	}
+	name, paths := flag.Arg(0), flag.Args()[1:]
+
+	s := schema.New()
+	for _, path := range paths {
+		// Skip unreadable archives rather than abandoning the corpus.
+		if err := addArchive(s, path, name); err != nil {
+			log.Printf("%s: %s", path, err)
+		}
+	}
+
+	var err error
+	if *structName != "" {
+		err = s.GoStructs(os.Stdout, *structName)
+	} else {
+		err = s.Report(os.Stdout)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
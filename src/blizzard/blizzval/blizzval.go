@@ -3,139 +3,289 @@ package blizzval
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
-	"sort"
+	"os"
 )
 
-func check(err error) {
-	if err != nil {
-		panic(err)
+// Value is a decoded value: one of []Value (array), string (blob), nil
+// (absent optional), map[int]Value, uint8, uint32, uint64, int64
+// (varint), BitArray or Variant.  Present optionals are represented by
+// their contents.
+type Value interface{}
+
+// Variant is a Value holding a variant of a choice: the variant's tag
+// and its value.
+type Variant struct {
+	Tag   int
+	Value Value
+}
+
+// Limits on the sizes that Decode accepts, which guard against
+// allocating huge amounts of memory for corrupt or hostile input.
+const (
+	// MaxLength is the largest array, map or blob length accepted.
+	MaxLength = 1 << 24
+
+	// MaxDepth is the deepest nesting of arrays and maps accepted.
+	MaxDepth = 256
+)
+
+var (
+	errVarIntOverflow = errors.New("varint overflows 64 bits")
+	errTooDeep        = errors.New("values nested too deeply")
+)
+
+// DecodeError describes a failure to decode a value.
+type DecodeError struct {
+	Offset int64 // offset of the failing value, from the start of decoding
+	Tag    byte  // tag byte of the failing value
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("blizzval: value at offset %d with tag %#x: %s", e.Offset, e.Tag, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// reader reads the primitives of the encoding, tracking its offset.
+type reader struct {
+	r   io.Reader
+	br  io.ByteReader // r, if it implements io.ByteReader
+	ofs int64
+	buf [8]byte
+}
+
+func newReader(r io.Reader) *reader {
+	br, _ := r.(io.ByteReader)
+	return &reader{r: r, br: br}
+}
+
+func (r *reader) read8() (uint8, error) {
+	if r.br != nil {
+		b, err := r.br.ReadByte()
+		if err == nil {
+			r.ofs++
+		}
+		return b, err
 	}
+	_, err := r.readFull(r.buf[:1])
+	return r.buf[0], err
 }
 
-func read8(r io.Reader) (val uint8) {
-	var buf [1]byte
-	_, err := r.Read(buf[:])
-	check(err)
-	return buf[0]
+func (r *reader) read32() (uint32, error) {
+	_, err := r.readFull(r.buf[:4])
+	return binary.LittleEndian.Uint32(r.buf[:]), err
 }
 
-func read32(r io.Reader) (val uint32) {
-	check(binary.Read(r, binary.LittleEndian, &val))
-	return
+func (r *reader) read64() (uint64, error) {
+	_, err := r.readFull(r.buf[:8])
+	return binary.LittleEndian.Uint64(r.buf[:]), err
 }
 
-type Value interface{}
+func (r *reader) readFull(buf []byte) (int, error) {
+	n, err := io.ReadFull(r.r, buf)
+	r.ofs += int64(n)
+	return n, err
+}
 
-// ReadVarInt reads the blizzval variable-length integer format.
-func ReadVarInt(r io.Reader) int64 {
-	var val int64
-	for ofs := uint(0); true; ofs += 7 {
-		b := read8(r)
-		val |= (int64(b) & 0x7f) << ofs
+func (r *reader) readVarInt() (int64, error) {
+	var val uint64
+	for shift := uint(0); ; shift += 7 {
+		b, err := r.read8()
+		if err != nil {
+			return 0, err
+		}
+		if shift > 63 || shift == 63 && b&0x7f > 1 {
+			return 0, errVarIntOverflow
+		}
+		val |= uint64(b&0x7f) << shift
 		if b&0x80 == 0 {
 			break
 		}
 	}
 	if val&1 != 0 {
-		return -(val >> 1)
-	} else {
-		return val >> 1
+		return -int64(val >> 1), nil
 	}
+	return int64(val >> 1), nil
 }
 
-// Read reads an encoded value from an io.Reader.
-func Read(r io.Reader) Value {
-	b := read8(r)
-	switch b {
+// readLength reads the varint length of an array, map or blob.
+func (r *reader) readLength() (int, error) {
+	n, err := r.readVarInt()
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 || n > MaxLength {
+		return 0, fmt.Errorf("bad length %d", n)
+	}
+	return int(n), nil
+}
+
+// unexpectedEOF converts io.EOF, which is only expected before the
+// start of a value, to io.ErrUnexpectedEOF.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// DecodeVarInt reads the blizzval variable-length integer format.
+func DecodeVarInt(r io.Reader) (int64, error) {
+	return newReader(r).readVarInt()
+}
+
+// ReadVarInt reads the blizzval variable-length integer format,
+// panicking on error.
+func ReadVarInt(r io.Reader) int64 {
+	val, err := DecodeVarInt(r)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// Decode reads an encoded value from an io.Reader.  It returns io.EOF
+// if the reader is empty, and a *DecodeError if the data is malformed
+// or ends partway through the value.
+//
+// Every choice is taken to hold a value.  A choice of a null variant,
+// which is encoded as nothing, can't be told apart without the schema;
+// Decode reads what follows it as its value.  Such values are read with
+// a Decoder instead, calling EndChoice for the null variants.
+func Decode(r io.Reader) (Value, error) {
+	return newReader(r).decode(0)
+}
+
+func (r *reader) decode(depth int) (Value, error) {
+	ofs := r.ofs
+	tag, err := r.read8()
+	if err != nil {
+		return nil, err
+	}
+	val, err := r.decodeTagged(tag, depth)
+	if err != nil {
+		if _, ok := err.(*DecodeError); !ok {
+			err = &DecodeError{Offset: ofs, Tag: tag, Err: unexpectedEOF(err)}
+		}
+		return nil, err
+	}
+	return val, nil
+}
+
+// decodeElem decodes a value nested within an array, map, optional or
+// choice.
+func (r *reader) decodeElem(depth int) (Value, error) {
+	if depth >= MaxDepth {
+		return nil, errTooDeep
+	}
+	val, err := r.decode(depth + 1)
+	return val, unexpectedEOF(err)
+}
+
+func (r *reader) decodeTagged(tag byte, depth int) (Value, error) {
+	switch tag {
 	case 0x0:
-		size := ReadVarInt(r)
-		array := make([]Value, size)
-		for i := 0; i < int(size); i++ {
-			array[i] = Read(r)
+		size, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
+		// Grow the array as elements arrive rather than trusting size.
+		capacity := size
+		if capacity > 1024 {
+			capacity = 1024
 		}
-		return array
+		array := make([]Value, 0, capacity)
+		for i := 0; i < size; i++ {
+			val, err := r.decodeElem(depth)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, val)
+		}
+		return array, nil
+	case 0x1:
+		size, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, (size+7)/8)
+		if _, err := r.readFull(buf); err != nil {
+			return nil, err
+		}
+		return BitArray{size, buf}, nil
 	case 0x2:
-		len := ReadVarInt(r)
-		buf := make([]byte, len)
-		_, err := r.Read(buf)
+		size, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size)
+		if _, err := r.readFull(buf); err != nil {
+			return nil, err
+		}
+		return string(buf), nil
+	case 0x3:
+		tag, err := r.readVarInt()
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
-		return string(buf)
+		val, err := r.decodeElem(depth)
+		if err != nil {
+			return nil, err
+		}
+		return Variant{int(tag), val}, nil
 	case 0x4:
-		if read8(r) == 0 {
-			return nil
+		present, err := r.read8()
+		if err != nil || present == 0 {
+			return nil, err
 		}
-		return Read(r)
+		return r.decodeElem(depth)
 	case 0x5:
-		size := ReadVarInt(r)
+		size, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
 		dict := map[int]Value{}
-		for i := 0; i < int(size); i++ {
-			key := ReadVarInt(r)
-			val := Read(r)
+		for i := 0; i < size; i++ {
+			key, err := r.readVarInt()
+			if err != nil {
+				return nil, err
+			}
+			val, err := r.decodeElem(depth)
+			if err != nil {
+				return nil, err
+			}
 			dict[int(key)] = val
 		}
-		return dict
+		return dict, nil
 	case 0x6:
-		return read8(r)
+		return r.read8()
 	case 0x7:
-		return read32(r)
+		return r.read32()
+	case 0x8:
+		return r.read64()
 	case 0x9:
-		return ReadVarInt(r)
+		return r.readVarInt()
 	default:
-		panic(fmt.Errorf("tracker event %x", b))
-	}
-
-	return nil
-}
-
-func printIndent(indent int) {
-	for i := 0; i < indent; i++ {
-		fmt.Printf("  ")
+		return nil, fmt.Errorf("unknown tag")
 	}
 }
 
-func printVal(e Value, indent int) {
-	switch e := e.(type) {
-	case map[int]Value:
-		keys := []int{}
-		for k, _ := range e {
-			keys = append(keys, k)
-		}
-		sort.Ints(keys)
-		fmt.Printf("{\n")
-		for _, k := range keys {
-			printIndent(indent + 1)
-			fmt.Printf("%d: ", k)
-			printVal(e[k], indent+1)
-			fmt.Printf("\n")
-		}
-		printIndent(indent)
-		fmt.Printf("}")
-	case []Value:
-		fmt.Printf("[\n")
-		for _, val := range e {
-			printIndent(indent + 1)
-			printVal(val, indent+1)
-			fmt.Printf("\n")
-		}
-	case string:
-		fmt.Printf("%q", e)
-	case uint8:
-		fmt.Printf("%#x", e)
-	case uint32:
-		fmt.Printf("%#x", e)
-	case int64:
-		fmt.Printf("%#x", e)
-	case nil:
-		fmt.Printf("nil")
-	default:
-		panic(fmt.Errorf("unhandled %T", e))
+// Read reads an encoded value from an io.Reader, panicking on error.
+func Read(r io.Reader) Value {
+	val, err := Decode(r)
+	if err != nil {
+		panic(err)
 	}
+	return val
 }
 
+// Dump prints v to standard output in the default Fprint format.
 func Dump(v Value) {
-	printVal(v, 0)
+	Fprint(os.Stdout, v, nil)
 }
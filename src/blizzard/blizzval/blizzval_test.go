@@ -0,0 +1,183 @@
+package blizzval
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"testing/quick"
+)
+
+func TestDecode(t *testing.T) {
+	for _, test := range []struct {
+		in  string
+		exp Value
+	}{
+		{"\x09\x04", int64(2)},
+		{"\x09\x05", int64(-2)},
+		{"\x09\x80\x01", int64(64)},
+		{"\x06\xff", uint8(0xff)},
+		{"\x07\x01\x02\x03\x04", uint32(0x04030201)},
+		{"\x02\x06abc", "abc"},
+		{"\x04\x00", nil},
+		{"\x04\x01\x09\x02", int64(1)},
+		{"\x00\x04\x09\x02\x06\x07", []Value{int64(1), uint8(7)}},
+		{"\x05\x04\x00\x02\x02x\x0a\x09\x00", map[int]Value{0: "x", 5: int64(0)}},
+	} {
+		// Read one byte at a time to catch short reads.
+		val, err := Decode(iotest.OneByteReader(strings.NewReader(test.in)))
+		if err != nil {
+			t.Errorf("%q: %s", test.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(val, test.exp) {
+			t.Errorf("%q: got %#v, expected %#v", test.in, val, test.exp)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, test := range []struct {
+		in     string
+		offset int64
+		tag    byte
+		err    error
+	}{
+		{"\x02\x06ab", 0, 0x2, io.ErrUnexpectedEOF},
+		{"\x00\x04\x09\x02", 0, 0x0, io.ErrUnexpectedEOF},
+		{"\x00\x04\x09\x02\x03", 4, 0x3, nil},
+		{"\x05\x02\x00\x02\x09x", 3, 0x2, nil},
+		{"\x02\x81\x80\x80\x10", 0, 0x2, nil},
+		{"\x09\xff\xff\xff\xff\xff\xff\xff\xff\xff\x7f", 0, 0x9, nil},
+	} {
+		_, err := Decode(bytes.NewReader([]byte(test.in)))
+		var de *DecodeError
+		if !errors.As(err, &de) {
+			t.Errorf("%q: got %v, expected DecodeError", test.in, err)
+			continue
+		}
+		if de.Offset != test.offset || de.Tag != test.tag {
+			t.Errorf("%q: error at offset %d tag %#x, expected %d %#x", test.in, de.Offset, de.Tag, test.offset, test.tag)
+		}
+		if test.err != nil && de.Err != test.err {
+			t.Errorf("%q: got %v, expected %v", test.in, de.Err, test.err)
+		}
+	}
+
+	if _, err := Decode(strings.NewReader("")); err != io.EOF {
+		t.Errorf("empty input: got %v, expected io.EOF", err)
+	}
+}
+
+func TestDecodeDepth(t *testing.T) {
+	in := strings.Repeat("\x04\x01", MaxDepth+1) + "\x09\x00"
+	if _, err := Decode(strings.NewReader(in)); err == nil {
+		t.Errorf("deeply nested value decoded without error")
+	}
+}
+
+// randValue generates random Values for property tests.
+type randValue struct {
+	v Value
+}
+
+func genValue(r *rand.Rand, depth int) Value {
+	n := 10
+	if depth > 3 {
+		n = 7 // no more containers
+	}
+	switch r.Intn(n) {
+	case 0:
+		return uint8(r.Intn(256))
+	case 1:
+		return r.Uint32()
+	case 2:
+		v := r.Int63() >> uint(r.Intn(63))
+		if r.Intn(2) == 0 {
+			v = -v
+		}
+		return v
+	case 3:
+		buf := make([]byte, r.Intn(20))
+		r.Read(buf)
+		return string(buf)
+	case 4:
+		return nil
+	case 5:
+		return r.Uint64()
+	case 6:
+		n := r.Intn(20)
+		buf := make([]byte, (n+7)/8)
+		r.Read(buf)
+		return BitArray{n, buf}
+	case 7:
+		arr := make([]Value, r.Intn(5))
+		for i := range arr {
+			arr[i] = genValue(r, depth+1)
+		}
+		return arr
+	case 8:
+		return Variant{r.Intn(10), genValue(r, depth+1)}
+	default:
+		m := map[int]Value{}
+		for i := r.Intn(5); i > 0; i-- {
+			m[r.Intn(40)-5] = genValue(r, depth+1)
+		}
+		return m
+	}
+}
+
+func (randValue) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(randValue{genValue(r, 0)})
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	roundTrip := func(rv randValue) bool {
+		var buf bytes.Buffer
+		if err := Encode(&buf, rv.v); err != nil {
+			t.Error(err)
+			return false
+		}
+		enc := buf.String()
+		val, err := Decode(&buf)
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		if buf.Len() != 0 {
+			t.Errorf("%d bytes left over", buf.Len())
+			return false
+		}
+		// Re-encoding must reproduce the same bytes.
+		buf.Reset()
+		Encode(&buf, val)
+		return reflect.DeepEqual(val, rv.v) && buf.String() == enc
+	}
+	if err := quick.Check(roundTrip, &quick.Config{MaxCount: 2000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	var buf bytes.Buffer
+	err := Encode(&buf, map[int]Value{10: uint8(1), 2: []Value{int64(-2), nil}, 0: "ab"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := "\x05\x06" + "\x00\x02\x04ab" + "\x04\x00\x04\x09\x05\x04\x00" + "\x14\x06\x01"
+	if buf.String() != exp {
+		t.Errorf("got %q, expected %q", buf.String(), exp)
+	}
+
+	if err := Encode(&buf, 3); err == nil {
+		t.Errorf("encoding an int succeeded")
+	}
+	if err := WriteVarInt(&buf, math.MinInt64); err == nil {
+		t.Errorf("encoding MinInt64 succeeded")
+	}
+}
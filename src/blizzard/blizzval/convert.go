@@ -0,0 +1,94 @@
+package blizzval
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The As functions convert Values to Go types for code generated by
+// blizzval/gen, returning an *UnmarshalError with an empty Path for a
+// mismatched type.
+
+// AsMap returns v as a map.
+func AsMap(v Value) (map[int]Value, error) {
+	m, ok := v.(map[int]Value)
+	if !ok {
+		return nil, &UnmarshalError{"", fmt.Sprintf("cannot store %s in struct", typeName(v))}
+	}
+	return m, nil
+}
+
+// AsArray returns v as an array.
+func AsArray(v Value) ([]Value, error) {
+	arr, ok := v.([]Value)
+	if !ok {
+		return nil, &UnmarshalError{"", fmt.Sprintf("cannot store %s in slice", typeName(v))}
+	}
+	return arr, nil
+}
+
+// AsString returns the blob v as a string.
+func AsString(v Value) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", &UnmarshalError{"", fmt.Sprintf("cannot store %s in string", typeName(v))}
+	}
+	return s, nil
+}
+
+// AsBytes returns a copy of the blob v.
+func AsBytes(v Value) ([]byte, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, &UnmarshalError{"", fmt.Sprintf("cannot store %s in []byte", typeName(v))}
+	}
+	return []byte(s), nil
+}
+
+// AsInt returns the integer v, which must lie within [min, max].
+func AsInt(v Value, min, max int64) (int64, error) {
+	n, ok := toInt64(v)
+	if !ok {
+		return 0, &UnmarshalError{"", fmt.Sprintf("cannot store %s in integer", typeName(v))}
+	}
+	if n < min || n > max {
+		return 0, &UnmarshalError{"", fmt.Sprintf("%d out of range [%d, %d]", n, min, max)}
+	}
+	return n, nil
+}
+
+// AsUint returns the integer v, which must lie within [0, max].  Unlike
+// AsInt, it accepts u64 values too large for an int64.
+func AsUint(v Value, max uint64) (uint64, error) {
+	u, ok := v.(uint64)
+	if !ok {
+		n, ok := toInt64(v)
+		if !ok {
+			return 0, &UnmarshalError{"", fmt.Sprintf("cannot store %s in integer", typeName(v))}
+		}
+		if n < 0 {
+			return 0, &UnmarshalError{"", fmt.Sprintf("%d out of range [0, %d]", n, max)}
+		}
+		u = uint64(n)
+	}
+	if u > max {
+		return 0, &UnmarshalError{"", fmt.Sprintf("%d out of range [0, %d]", u, max)}
+	}
+	return u, nil
+}
+
+// WrapError places err at path.  If err is an *UnmarshalError, the
+// first element of its Path, naming the type it was reported against,
+// is replaced by path, so that "Player.Name" wrapped with
+// "Details.Players[2]" becomes "Details.Players[2].Name".
+func WrapError(path string, err error) error {
+	e, ok := err.(*UnmarshalError)
+	if !ok {
+		return &UnmarshalError{path, err.Error()}
+	}
+	rest := ""
+	if i := strings.IndexAny(e.Path, ".["); i >= 0 {
+		rest = e.Path[i:]
+	}
+	return &UnmarshalError{path + rest, e.Msg}
+}
@@ -0,0 +1,281 @@
+package blizzval
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Kind identifies the kind of a Token.
+type Kind int
+
+const (
+	KindNull       Kind = iota // an absent optional
+	KindInt                    // a u8, u32, u64 or varint, in Token.Int
+	KindBlob                   // a blob, in Token.Bytes
+	KindStartArray             // the start of an array of Token.Len elements
+	KindEndArray               // the end of an array
+	KindStartMap               // the start of a map of Token.Len entries
+	KindEndMap                 // the end of a map
+	KindChoice                 // a choice of variant Token.Int, whose value follows
+	KindBitArray               // a bit array of Token.Len bits, in Token.Bytes
+)
+
+var kindNames = []string{"null", "int", "blob", "start array", "end array", "start map", "end map", "choice", "bit array"}
+
+func (k Kind) String() string {
+	if k >= 0 && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Token is a single step of a decoded value.  Present optionals are
+// transparent: their contents are returned in their place.  A choice
+// is returned as a KindChoice token followed by the tokens of its
+// value.
+type Token struct {
+	Kind Kind
+
+	// HasKey is set on tokens for values within a map, with Key
+	// holding the value's map key.
+	HasKey bool
+	Key    int
+
+	// Tag is the wire tag of the value, which distinguishes u8 (0x6),
+	// u32 (0x7), u64 (0x8) and varint (0x9) integers.  It is 0 for end
+	// tokens.
+	Tag byte
+
+	// Int is the value of a KindInt token, or the variant of a
+	// KindChoice one.  A u64 is stored as its bits, so values above
+	// math.MaxInt64 are negative.
+	Int int64
+
+	// Bytes is the contents of a KindBlob or KindBitArray token, the
+	// latter padded to whole bytes.  It aliases a buffer in the
+	// Decoder and is only valid until the next call to Token.
+	Bytes []byte
+
+	// Len is the number of elements or entries of a start token, or
+	// of bits of a KindBitArray token.
+	Len int
+}
+
+// frame is an array, map or choice whose tokens are being returned.
+type frame struct {
+	ofs  int64 // offset of the container's tag
+	tag  byte
+	left int // elements or entries yet to be returned
+}
+
+// Decoder reads a stream of encoded values as tokens, without
+// building a Value for each.
+type Decoder struct {
+	r        *reader
+	stack    []frame
+	buf      []byte
+	skipping bool // discard blob contents rather than buffering them
+	ended    bool // the last token was a choice ended by EndChoice
+	err      error
+}
+
+// NewDecoder returns a Decoder reading from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: newReader(r)}
+}
+
+// InputOffset returns the offset of the next byte to be read.
+func (d *Decoder) InputOffset() int64 {
+	return d.r.ofs
+}
+
+// Token returns the next token.  It returns io.EOF if the input ends
+// between top-level values, and a *DecodeError, as Decode does, if the
+// input is malformed.  Errors are sticky.
+func (d *Decoder) Token() (Token, error) {
+	if d.err != nil {
+		return Token{}, d.err
+	}
+	d.ended = false
+	tok, err := d.token()
+	if err != nil {
+		d.err = err
+		return tok, err
+	}
+	if tok.Kind != KindStartArray && tok.Kind != KindStartMap && tok.Kind != KindChoice {
+		d.endChoices()
+	}
+	return tok, nil
+}
+
+// endChoices pops the choices holding a value that is complete.
+func (d *Decoder) endChoices() {
+	n := len(d.stack)
+	for n > 0 && d.stack[n-1].tag == 0x3 {
+		n--
+	}
+	d.stack = d.stack[:n]
+}
+
+// EndChoice ends the choice whose KindChoice token was just returned
+// without reading a value, for variants such as null ones that are
+// encoded as nothing.
+func (d *Decoder) EndChoice() error {
+	if d.err != nil {
+		return d.err
+	}
+	n := len(d.stack)
+	if n == 0 || d.stack[n-1].tag != 0x3 || d.stack[n-1].left != 1 {
+		return errors.New("blizzval: EndChoice outside a choice")
+	}
+	d.stack = d.stack[:n-1]
+	d.endChoices()
+	d.ended = true
+	return nil
+}
+
+func (d *Decoder) token() (Token, error) {
+	var tok Token
+
+	// The container, if any, that errors before the next value's tag
+	// are reported against.
+	nested, errOfs, errTag := false, int64(0), byte(0)
+	if n := len(d.stack); n > 0 {
+		top := &d.stack[n-1]
+		if top.left == 0 {
+			d.stack = d.stack[:n-1]
+			if top.tag == 0x5 {
+				tok.Kind = KindEndMap
+			} else {
+				tok.Kind = KindEndArray
+			}
+			return tok, nil
+		}
+		top.left--
+		nested, errOfs, errTag = true, top.ofs, top.tag
+		if top.tag == 0x5 {
+			key, err := d.r.readVarInt()
+			if err != nil {
+				return tok, &DecodeError{Offset: errOfs, Tag: errTag, Err: unexpectedEOF(err)}
+			}
+			tok.HasKey, tok.Key = true, int(key)
+		}
+	}
+
+	ofs := d.r.ofs
+	tag, err := d.r.read8()
+	for err == nil && tag == 0x4 {
+		var present byte
+		nested, errOfs, errTag = true, ofs, tag
+		if present, err = d.r.read8(); err != nil {
+			break
+		}
+		if present == 0 {
+			tok.Kind, tok.Tag = KindNull, tag
+			return tok, nil
+		}
+		ofs = d.r.ofs
+		tag, err = d.r.read8()
+	}
+	if err != nil {
+		if err == io.EOF && !nested {
+			return tok, io.EOF
+		}
+		return tok, &DecodeError{Offset: errOfs, Tag: errTag, Err: unexpectedEOF(err)}
+	}
+
+	tok.Tag = tag
+	if err := d.value(&tok, ofs); err != nil {
+		return tok, &DecodeError{Offset: ofs, Tag: tag, Err: unexpectedEOF(err)}
+	}
+	return tok, nil
+}
+
+// value reads the remainder of the value with tag tok.Tag.
+func (d *Decoder) value(tok *Token, ofs int64) error {
+	var err error
+	switch tok.Tag {
+	case 0x0, 0x5:
+		if len(d.stack) >= MaxDepth {
+			return errTooDeep
+		}
+		if tok.Len, err = d.r.readLength(); err != nil {
+			return err
+		}
+		tok.Kind = KindStartArray
+		if tok.Tag == 0x5 {
+			tok.Kind = KindStartMap
+		}
+		d.stack = append(d.stack, frame{ofs, tok.Tag, tok.Len})
+	case 0x3:
+		if len(d.stack) >= MaxDepth {
+			return errTooDeep
+		}
+		tok.Kind = KindChoice
+		if tok.Int, err = d.r.readVarInt(); err != nil {
+			return err
+		}
+		d.stack = append(d.stack, frame{ofs, tok.Tag, 1})
+	case 0x1, 0x2:
+		size, err := d.r.readLength()
+		if err != nil {
+			return err
+		}
+		tok.Kind = KindBlob
+		if tok.Tag == 0x1 {
+			tok.Kind, tok.Len, size = KindBitArray, size, (size+7)/8
+		}
+		if d.skipping {
+			n, err := io.CopyN(io.Discard, d.r.r, int64(size))
+			d.r.ofs += n
+			return err
+		}
+		if cap(d.buf) < size {
+			d.buf = make([]byte, size)
+		}
+		tok.Bytes = d.buf[:size]
+		_, err = d.r.readFull(tok.Bytes)
+		return err
+	case 0x6:
+		b, err := d.r.read8()
+		tok.Kind, tok.Int = KindInt, int64(b)
+		return err
+	case 0x7:
+		u, err := d.r.read32()
+		tok.Kind, tok.Int = KindInt, int64(u)
+		return err
+	case 0x8:
+		u, err := d.r.read64()
+		tok.Kind, tok.Int = KindInt, int64(u)
+		return err
+	case 0x9:
+		tok.Kind = KindInt
+		tok.Int, err = d.r.readVarInt()
+	default:
+		err = fmt.Errorf("unknown tag")
+	}
+	return err
+}
+
+// Skip reads tokens until it has consumed the end of the most recently
+// started array, map or choice, discarding blob contents unread.
+// Called after a start or choice token it skips that whole subtree;
+// called between top-level values, or after a choice ended by
+// EndChoice, it does nothing.  As with Decode, the choices within the
+// skipped subtree are taken to hold values, so a subtree holding a null
+// variant must be read token by token, ending the variant with
+// EndChoice.
+func (d *Decoder) Skip() error {
+	if d.ended {
+		return nil
+	}
+	d.skipping = true
+	defer func() { d.skipping = false }()
+	for depth := len(d.stack); depth > 0 && len(d.stack) >= depth; {
+		if _, err := d.Token(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
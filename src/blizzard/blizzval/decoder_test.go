@@ -0,0 +1,335 @@
+package blizzval
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"testing/quick"
+)
+
+// decodeTokens builds a Value from the tokens of d, to compare
+// against Decode.
+func decodeTokens(d *Decoder) (Value, error) {
+	tok, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+	return tokenValue(d, tok)
+}
+
+func tokenValue(d *Decoder, tok Token) (Value, error) {
+	switch tok.Kind {
+	case KindNull:
+		return nil, nil
+	case KindInt:
+		switch tok.Tag {
+		case 0x6:
+			return uint8(tok.Int), nil
+		case 0x7:
+			return uint32(tok.Int), nil
+		case 0x8:
+			return uint64(tok.Int), nil
+		}
+		return tok.Int, nil
+	case KindBlob:
+		return string(tok.Bytes), nil
+	case KindBitArray:
+		return BitArray{tok.Len, append([]byte{}, tok.Bytes...)}, nil
+	case KindChoice:
+		val, err := decodeTokens(d)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		return Variant{int(tok.Int), val}, nil
+	case KindStartArray:
+		arr := []Value{}
+		for {
+			tok, err := d.Token()
+			if err != nil {
+				return nil, err
+			}
+			if tok.Kind == KindEndArray {
+				return arr, nil
+			}
+			val, err := tokenValue(d, tok)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, val)
+		}
+	case KindStartMap:
+		m := map[int]Value{}
+		for {
+			tok, err := d.Token()
+			if err != nil {
+				return nil, err
+			}
+			if tok.Kind == KindEndMap {
+				return m, nil
+			}
+			if !tok.HasKey {
+				return nil, errors.New("map entry without key")
+			}
+			key := tok.Key
+			val, err := tokenValue(d, tok)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = val
+		}
+	}
+	return nil, errors.New("unexpected " + tok.Kind.String())
+}
+
+func TestDecoderTokens(t *testing.T) {
+	in := "\x05\x04" + "\x00\x00\x04\x09\x02\x04\x00" + "\x0a\x04\x01\x02\x04ab"
+	d := NewDecoder(iotest.OneByteReader(strings.NewReader(in)))
+	exp := []Token{
+		{Kind: KindStartMap, Tag: 0x5, Len: 2},
+		{Kind: KindStartArray, HasKey: true, Key: 0, Tag: 0x0, Len: 2},
+		{Kind: KindInt, Tag: 0x9, Int: 1},
+		{Kind: KindNull, Tag: 0x4},
+		{Kind: KindEndArray},
+		{Kind: KindBlob, HasKey: true, Key: 5, Tag: 0x2, Bytes: []byte("ab")},
+		{Kind: KindEndMap},
+	}
+	for i, e := range exp {
+		tok, err := d.Token()
+		if err != nil {
+			t.Fatalf("token %d: %s", i, err)
+		}
+		if !reflect.DeepEqual(tok, e) {
+			t.Errorf("token %d: got %+v, expected %+v", i, tok, e)
+		}
+	}
+	if _, err := d.Token(); err != io.EOF {
+		t.Errorf("got %v at end, expected io.EOF", err)
+	}
+	if d.InputOffset() != int64(len(in)) {
+		t.Errorf("offset %d, expected %d", d.InputOffset(), len(in))
+	}
+}
+
+func TestDecoderSkip(t *testing.T) {
+	in := "\x05\x04" + "\x00\x00\x04\x05\x02\x04\x02\x04ab\x09\x02" + "\x02\x09\x08" + "\x09\x02"
+	d := NewDecoder(strings.NewReader(in))
+	if err := d.Skip(); err != nil {
+		t.Fatal(err)
+	}
+	d.Token() // start map
+	if tok, _ := d.Token(); tok.Kind != KindStartArray || tok.Key != 0 {
+		t.Fatalf("got %+v, expected start array", tok)
+	}
+	if err := d.Skip(); err != nil {
+		t.Fatal(err)
+	}
+	if tok, _ := d.Token(); tok.Kind != KindInt || tok.Key != 1 || tok.Int != 4 {
+		t.Errorf("after skip got %+v, expected key 1 int 4", tok)
+	}
+	if err := d.Skip(); err != nil {
+		t.Fatal(err)
+	}
+	if tok, _ := d.Token(); tok.Kind != KindInt || tok.HasKey || tok.Int != 1 {
+		t.Errorf("after skipping map got %+v, expected top-level int 1", tok)
+	}
+}
+
+func TestDecoderChoice(t *testing.T) {
+	in := "\x03\x02\x09\x04" + "\x05\x02\x00\x03\x02\x02\x02x" + "\x09\x02"
+	exp := []Token{
+		{Kind: KindChoice, Tag: 0x3, Int: 1},
+		{Kind: KindInt, Tag: 0x9, Int: 2},
+		{Kind: KindStartMap, Tag: 0x5, Len: 1},
+		{Kind: KindChoice, HasKey: true, Key: 0, Tag: 0x3, Int: 1},
+		{Kind: KindBlob, Tag: 0x2, Bytes: []byte("x")},
+		{Kind: KindEndMap},
+		{Kind: KindInt, Tag: 0x9, Int: 1},
+	}
+	d := NewDecoder(strings.NewReader(in))
+	for i, e := range exp {
+		tok, err := d.Token()
+		if err != nil {
+			t.Fatalf("token %d: %s", i, err)
+		}
+		if !reflect.DeepEqual(tok, e) {
+			t.Errorf("token %d: got %+v, expected %+v", i, tok, e)
+		}
+	}
+
+	d = NewDecoder(strings.NewReader(in))
+	d.Token()
+	if err := d.Skip(); err != nil {
+		t.Fatal(err)
+	}
+	d.Token() // start map
+	d.Token() // choice
+	if err := d.Skip(); err != nil {
+		t.Fatal(err)
+	}
+	if tok, _ := d.Token(); tok.Kind != KindEndMap {
+		t.Errorf("after skipping choice got %+v, expected end map", tok)
+	}
+
+	// A choice of a null variant is followed by nothing.
+	d = NewDecoder(strings.NewReader("\x05\x04" + "\x00\x03\x00" + "\x02\x09\x04"))
+	d.Token() // start map
+	d.Token() // choice
+	if err := d.EndChoice(); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Skip(); err != nil {
+		t.Fatal(err)
+	}
+	if tok, _ := d.Token(); tok.Kind != KindInt || tok.Key != 1 || tok.Int != 2 {
+		t.Errorf("after ending choice got %+v, expected key 1 int 2", tok)
+	}
+	if tok, _ := d.Token(); tok.Kind != KindEndMap {
+		t.Errorf("got %+v, expected end map", tok)
+	}
+	if err := d.EndChoice(); err == nil {
+		t.Errorf("ended a choice outside a choice")
+	}
+
+	d = NewDecoder(strings.NewReader("\x03\x02"))
+	d.Token()
+	var de *DecodeError
+	if _, err := d.Token(); !errors.As(err, &de) || de.Offset != 0 || de.Tag != 0x3 || de.Err != io.ErrUnexpectedEOF {
+		t.Errorf("truncated choice: got %v", err)
+	}
+}
+
+// TestDecoderSkipNullChoice skips through an array of structs whose
+// first field is a choice, a null variant in the first struct, ending
+// the null choice with EndChoice as a caller knowing the schema does.
+func TestDecoderSkipNullChoice(t *testing.T) {
+	in := "\x00\x04" +
+		"\x05\x04" + "\x00\x03\x00" + "\x02\x09\x04" +
+		"\x05\x04" + "\x00\x03\x02\x05\x02\x00\x09\x02" + "\x02\x09\x06"
+	d := NewDecoder(strings.NewReader(in))
+	d.Token() // start array
+	for i, exp := range []int64{2, 3} {
+		d.Token() // start map
+		tok, err := d.Token()
+		if err != nil || tok.Kind != KindChoice {
+			t.Fatalf("%d: got %+v, %v, expected choice", i, tok, err)
+		}
+		if tok.Int == 0 {
+			err = d.EndChoice()
+		} else {
+			err = d.Skip()
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if tok, _ := d.Token(); tok.Kind != KindInt || tok.Key != 1 || tok.Int != exp {
+			t.Errorf("%d: after choice got %+v, expected key 1 int %d", i, tok, exp)
+		}
+		// Skip the rest of the struct, its end.
+		if err := d.Skip(); err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+	}
+	if tok, _ := d.Token(); tok.Kind != KindEndArray {
+		t.Errorf("got %+v, expected end array", tok)
+	}
+	if _, err := d.Token(); err != io.EOF {
+		t.Errorf("got %v at end, expected io.EOF", err)
+	}
+}
+
+func TestDecoderBitArray(t *testing.T) {
+	// 10 bits, padded to 2 bytes, then an int.
+	d := NewDecoder(strings.NewReader("\x01\x14\x03\xff\x09\x02"))
+	tok, err := d.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := (Token{Kind: KindBitArray, Tag: 0x1, Len: 10, Bytes: []byte{0x03, 0xff}}); !reflect.DeepEqual(tok, exp) {
+		t.Errorf("got %+v, expected %+v", tok, exp)
+	}
+	if tok, _ := d.Token(); tok.Kind != KindInt || tok.Int != 1 {
+		t.Errorf("after bit array got %+v, expected int 1", tok)
+	}
+
+	d = NewDecoder(strings.NewReader("\x01\x14\x03"))
+	var de *DecodeError
+	if _, err := d.Token(); !errors.As(err, &de) || de.Tag != 0x1 || de.Err != io.ErrUnexpectedEOF {
+		t.Errorf("truncated bit array: got %v", err)
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	// Token errors should match those of Decode.
+	for _, in := range []string{
+		"\x02\x06ab",
+		"\x00\x04\x09\x02",
+		"\x00\x04\x09\x02\x0a",
+		"\x05\x02\x00\x02\x09x",
+		"\x05\x02",
+		"\x04\x01",
+		"\x02\x81\x80\x80\x10",
+		"\x09\xff\xff\xff\xff\xff\xff\xff\xff\xff\x7f",
+		"\x08\x01\x02\x03",
+		"\x01\x14\x03",
+		"\x00\x02\x03\x02",
+	} {
+		_, exp := Decode(strings.NewReader(in))
+		_, err := decodeTokens(NewDecoder(strings.NewReader(in)))
+		if !reflect.DeepEqual(err, exp) {
+			t.Errorf("%q: got %v, expected %v", in, err, exp)
+		}
+	}
+
+	d := NewDecoder(strings.NewReader(strings.Repeat("\x00\x02", MaxDepth+1)))
+	var err error
+	for err == nil {
+		_, err = d.Token()
+	}
+	if !strings.Contains(err.Error(), errTooDeep.Error()) {
+		t.Errorf("deep nesting: got %v", err)
+	}
+}
+
+func TestDecoderRoundTrip(t *testing.T) {
+	check := func(rv randValue) bool {
+		var buf bytes.Buffer
+		if err := Encode(&buf, rv.v); err != nil {
+			t.Error(err)
+			return false
+		}
+		val, err := decodeTokens(NewDecoder(&buf))
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		return reflect.DeepEqual(val, rv.v)
+	}
+	if err := quick.Check(check, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDecoderAllocs(t *testing.T) {
+	var buf bytes.Buffer
+	Encode(&buf, map[int]Value{0: []Value{int64(1), "abc", uint8(2)}, 1: "xyz", 2: nil})
+	enc := bytes.Repeat(buf.Bytes(), 100)
+	r := bytes.NewReader(enc)
+	d := NewDecoder(r)
+	allocs := testing.AllocsPerRun(10, func() {
+		r.Reset(enc)
+		*d = Decoder{r: d.r, stack: d.stack[:0], buf: d.buf}
+		d.r.ofs = 0
+		for {
+			if _, err := d.Token(); err != nil {
+				break
+			}
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("%v allocations per run, expected 0", allocs)
+	}
+}
@@ -0,0 +1,112 @@
+package blizzval
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+var errVarIntRange = errors.New("blizzval: varint out of range")
+
+func appendVarInt(buf []byte, val int64) ([]byte, error) {
+	var u uint64
+	switch {
+	case val == math.MinInt64:
+		// The magnitude doesn't fit alongside the sign bit.
+		return buf, errVarIntRange
+	case val < 0:
+		u = uint64(-val)<<1 | 1
+	default:
+		u = uint64(val) << 1
+	}
+	for u >= 0x80 {
+		buf = append(buf, byte(u)|0x80)
+		u >>= 7
+	}
+	return append(buf, byte(u)), nil
+}
+
+// WriteVarInt writes val in the blizzval variable-length integer format.
+func WriteVarInt(w io.Writer, val int64) error {
+	var buf [10]byte
+	enc, err := appendVarInt(buf[:0], val)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(enc)
+	return err
+}
+
+func appendValue(buf []byte, v Value) ([]byte, error) {
+	var err error
+	switch v := v.(type) {
+	case []Value:
+		buf = append(buf, 0x0)
+		buf, _ = appendVarInt(buf, int64(len(v)))
+		for _, elem := range v {
+			if buf, err = appendValue(buf, elem); err != nil {
+				return buf, err
+			}
+		}
+	case string:
+		buf = append(buf, 0x2)
+		buf, _ = appendVarInt(buf, int64(len(v)))
+		buf = append(buf, v...)
+	case nil:
+		buf = append(buf, 0x4, 0)
+	case map[int]Value:
+		keys := make([]int, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Ints(keys)
+		buf = append(buf, 0x5)
+		buf, _ = appendVarInt(buf, int64(len(v)))
+		for _, k := range keys {
+			if buf, err = appendVarInt(buf, int64(k)); err != nil {
+				return buf, err
+			}
+			if buf, err = appendValue(buf, v[k]); err != nil {
+				return buf, err
+			}
+		}
+	case uint8:
+		buf = append(buf, 0x6, v)
+	case uint32:
+		buf = append(buf, 0x7)
+		buf = binary.LittleEndian.AppendUint32(buf, v)
+	case uint64:
+		buf = append(buf, 0x8)
+		buf = binary.LittleEndian.AppendUint64(buf, v)
+	case int64:
+		buf = append(buf, 0x9)
+		return appendVarInt(buf, v)
+	case Variant:
+		buf = append(buf, 0x3)
+		if buf, err = appendVarInt(buf, int64(v.Tag)); err != nil {
+			return buf, err
+		}
+		return appendValue(buf, v.Value)
+	case TypedValue:
+		return v.appendTo(buf)
+	default:
+		return buf, fmt.Errorf("blizzval: cannot encode %T", v)
+	}
+	return buf, nil
+}
+
+// Encode writes v to w.  nil is written as an absent optional, and map
+// entries are written in ascending key order, so equal values always
+// encode identically.  v may also be, or contain, a TypedValue, which
+// is written with its wire types and field order preserved.
+func Encode(w io.Writer, v Value) error {
+	buf, err := appendValue(nil, v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+	return err
+}
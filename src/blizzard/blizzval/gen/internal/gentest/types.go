@@ -0,0 +1,27 @@
+// Package gentest holds structs exercising the features of
+// blizzval/gen, with the generated code checked in alongside.
+package gentest
+
+import "blizzard/blizzval"
+
+//go:generate go run blizzard/blizzval/gen -in types.go -out types_proto.go
+
+type Unit struct {
+	Raw   blizzval.Value
+	Name  string  `blizz:"0"`
+	Owner uint8   `blizz:"1"`
+	Tag   uint32  `blizz:"2"`
+	Life  int32   `blizz:"3"`
+	Dead  bool    `blizz:"4"`
+	Color *uint32 `blizz:"5,omitempty"`
+}
+
+type Game struct {
+	Units   []*Unit        `blizz:"0"`
+	Leader  Unit           `blizz:"1"`
+	Rival   *Unit          `blizz:"2"`
+	Hash    []byte         `blizz:"3"`
+	Grid    [][]int16      `blizz:"4"`
+	Extra   blizzval.Value `blizz:"5,omitempty"`
+	Version uint64         `blizz:"6"`
+}
@@ -0,0 +1,78 @@
+package gentest
+
+import (
+	"reflect"
+	"testing"
+
+	"blizzard/blizzval"
+)
+
+func unitValue(name string) map[int]blizzval.Value {
+	return map[int]blizzval.Value{
+		0: name, 1: uint8(2), 2: uint32(3), 3: int64(-4), 4: uint8(1),
+		9: "unknown",
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	color := unitValue("b")
+	color[5] = uint32(0xff0000)
+	v := map[int]blizzval.Value{
+		0: []blizzval.Value{unitValue("a"), nil, color},
+		1: unitValue("leader"),
+		2: nil,
+		3: "\x01\x02",
+		4: []blizzval.Value{[]blizzval.Value{int64(1), int64(-2)}, []blizzval.Value{}},
+		6: uint64(1<<63 + 7),
+	}
+	g, err := readGame(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Units) != 3 || g.Units[1] != nil || *g.Units[2].Color != 0xff0000 {
+		t.Errorf("bad units %+v", g.Units)
+	}
+	if g.Leader.Name != "leader" || g.Leader.Life != -4 || !g.Leader.Dead || g.Leader.Color != nil {
+		t.Errorf("bad leader %+v", g.Leader)
+	}
+	if g.Rival != nil || string(g.Hash) != "\x01\x02" || g.Grid[0][1] != -2 || g.Version != 1<<63+7 {
+		t.Errorf("bad game %+v", g)
+	}
+
+	// Raw preserves the unknown key 9 of units, but not of Game.
+	if enc := encodeGame(g); !reflect.DeepEqual(enc, blizzval.Value(v)) {
+		t.Errorf("encoded as\n%#v\nexpected\n%#v", enc, v)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	bad := unitValue("a")
+	bad[3] = int64(1 << 40)
+	v := map[int]blizzval.Value{0: []blizzval.Value{unitValue("a"), bad}}
+	_, err := readGame(v)
+	exp := "blizzval: Game.Units[1].Life: 1099511627776 out of range [-2147483648, 2147483647]"
+	if err == nil || err.Error() != exp {
+		t.Errorf("got error %v, expected %q", err, exp)
+	}
+
+	missing := unitValue("a")
+	delete(missing, 4)
+	_, err = readUnit(missing)
+	exp = "blizzval: Unit.Dead: missing key 4"
+	if err == nil || err.Error() != exp {
+		t.Errorf("got error %v, expected %q", err, exp)
+	}
+
+	_, err = readGame(map[int]blizzval.Value{0: []blizzval.Value{"x"}})
+	exp = "blizzval: Game.Units[0]: cannot store blob in struct"
+	if err == nil || err.Error() != exp {
+		t.Errorf("got error %v, expected %q", err, exp)
+	}
+
+	_, err = readGame(map[int]blizzval.Value{0: []blizzval.Value{}, 1: unitValue("a"), 2: nil, 3: "",
+		4: []blizzval.Value{}, 6: int64(-1)})
+	exp = "blizzval: Game.Version: -1 out of range [0, 18446744073709551615]"
+	if err == nil || err.Error() != exp {
+		t.Errorf("got error %v, expected %q", err, exp)
+	}
+}
@@ -1,33 +1,79 @@
+// Command gen generates blizzval decoders and encoders for the structs
+// in a Go file.
+//
+// Each struct with fields tagged like `blizz:"5"` gets a function
+//
+//	func readT(v blizzval.Value) (*T, error)
+//
+// that decodes T from a map, following the rules of blizzval.Unmarshal
+// without reflection, and a function
+//
+//	func encodeT(in *T) blizzval.Value
+//
+// that does the reverse.  A field named Raw of type blizzval.Value
+// receives the whole map when decoding, and when encoding supplies the
+// entries of untagged keys.
+//
+// Field types may be string, []byte, bool, sized integers, other
+// tagged structs, blizzval.Value, and pointers to and slices of these.
+// Integers are encoded as u8 for uint8 and bool, u32 for uint32, u64
+// for uint64, and varints otherwise.
 package main
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
 	"go/ast"
+	"go/format"
 	"go/parser"
 	"go/token"
-	"io"
 	"log"
 	"os"
+	"reflect"
 	"strconv"
+	"strings"
 )
 
 type protoField struct {
-	Key   int
-	Name  string
-	Type  string
-	Slice bool
+	Key       int
+	Name      string
+	Type      ast.Expr
+	OmitEmpty bool
 }
 type protoStruct struct {
 	Name   string
+	Raw    bool // has a Raw blizzval.Value field
 	Fields []*protoField
 }
 
-func read(path string) (ps []*protoStruct) {
+// intRanges gives the range of each supported integer type.
+var intRanges = map[string][2]string{
+	"int8":   {"-1 << 7", "1<<7 - 1"},
+	"int16":  {"-1 << 15", "1<<15 - 1"},
+	"int32":  {"-1 << 31", "1<<31 - 1"},
+	"int64":  {"-1 << 63", "1<<63 - 1"},
+	"uint8":  {"0", "1<<8 - 1"},
+	"byte":   {"0", "1<<8 - 1"},
+	"uint16": {"0", "1<<16 - 1"},
+	"uint32": {"0", "1<<32 - 1"},
+	"bool":   {"-1 << 63", "1<<63 - 1"},
+}
+
+func isValue(t ast.Expr) bool {
+	sel, ok := t.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "blizzval" && sel.Sel.Name == "Value"
+}
+
+func read(path string) (pkg string, ps []*protoStruct, err error) {
 	fset := token.NewFileSet()
 	f, err := parser.ParseFile(fset, path, nil, 0)
 	if err != nil {
-		panic(err)
+		return "", nil, err
 	}
 
 	for _, d := range f.Decls {
@@ -46,25 +92,28 @@ func read(path string) (ps []*protoStruct) {
 			}
 
 			for _, f := range t.Fields.List {
+				if len(f.Names) == 1 && f.Names[0].Name == "Raw" && isValue(f.Type) {
+					p.Raw = true
+				}
 				if f.Tag == nil {
 					continue
 				}
-				pf := &protoField{}
-				tag := f.Tag.Value
-				tag = tag[1 : len(tag)-1] // strip "
-				pf.Key, err = strconv.Atoi(tag)
+				tag, err := strconv.Unquote(f.Tag.Value)
 				if err != nil {
-					panic(err)
+					return "", nil, err
+				}
+				key, opts, _ := strings.Cut(reflect.StructTag(tag).Get("blizz"), ",")
+				if key == "" || key == "-" {
+					continue
+				}
+				pf := &protoField{
+					Name:      f.Names[0].Name,
+					Type:      f.Type,
+					OmitEmpty: opts == "omitempty",
 				}
-				pf.Name = f.Names[0].Name
-				switch t := f.Type.(type) {
-				case *ast.Ident:
-					pf.Type = fmt.Sprintf("%s", t)
-				case *ast.ArrayType:
-					pf.Type = fmt.Sprintf("%s", t.Elt.(*ast.StarExpr).X)
-					pf.Slice = true
-				default:
-					panic(t)
+				pf.Key, err = strconv.Atoi(key)
+				if err != nil {
+					return "", nil, fmt.Errorf("%s.%s: bad key %q", p.Name, pf.Name, key)
 				}
 				p.Fields = append(p.Fields, pf)
 			}
@@ -73,45 +122,278 @@ func read(path string) (ps []*protoStruct) {
 			}
 		}
 	}
-	return
+	return f.Name.Name, ps, nil
 }
 
-type w struct {
-	io.Writer
+// path is a field path for error messages, as a format string with
+// the names of the index variables it refers to.
+type path struct {
+	format string
+	args   []string
 }
 
-func (w *w) Print(s string, args ...interface{}) {
-	fmt.Fprintf(w, s+"\n", args...)
+func (p path) index(i string) path {
+	return path{p.format + "[%d]", append(p.args[:len(p.args):len(p.args)], i)}
 }
 
-func main() {
-	inf := flag.String("in", "", "input filename")
-	flag.Parse()
+// expr returns a Go expression evaluating to the path.
+func (p path) expr() string {
+	if len(p.args) == 0 {
+		return strconv.Quote(p.format)
+	}
+	return fmt.Sprintf("fmt.Sprintf(%s, %s)", strconv.Quote(p.format), strings.Join(p.args, ", "))
+}
+
+type gen struct {
+	bytes.Buffer
+	structs map[string]bool
+	usesFmt bool
+	n       int // counter for unique variable names
+}
+
+func (g *gen) Print(s string, args ...interface{}) {
+	fmt.Fprintf(g, s+"\n", args...)
+}
+
+// name returns a variable name unique within the generated file.
+func (g *gen) name(prefix string) string {
+	g.n++
+	return fmt.Sprintf("%s%d", prefix, g.n)
+}
+
+func (g *gen) fail(p path) {
+	if len(p.args) > 0 {
+		g.usesFmt = true
+	}
+	g.Print("if err != nil {")
+	g.Print("return nil, blizzval.WrapError(%s, err)", p.expr())
+	g.Print("}")
+}
+
+func typeString(t ast.Expr) string {
+	var buf bytes.Buffer
+	format.Node(&buf, token.NewFileSet(), t)
+	return buf.String()
+}
+
+// decode emits code decoding the Value src into dst, of type t.
+func (g *gen) decode(t ast.Expr, src, dst string, p path) error {
+	switch t := t.(type) {
+	case *ast.Ident:
+		if g.structs[t.Name] {
+			v := g.name("p")
+			g.Print("%s, err := read%s(%s)", v, t.Name, src)
+			g.fail(p)
+			g.Print("%s = *%s", dst, v)
+			return nil
+		}
+		if t.Name == "uint64" {
+			// AsInt's int64 bounds can't hold the whole range.
+			v := g.name("n")
+			g.Print("%s, err := blizzval.AsUint(%s, 1<<64 - 1)", v, src)
+			g.fail(p)
+			g.Print("%s = %s", dst, v)
+			return nil
+		}
+		if t.Name == "string" {
+			v := g.name("s")
+			g.Print("%s, err := blizzval.AsString(%s)", v, src)
+			g.fail(p)
+			g.Print("%s = %s", dst, v)
+			return nil
+		}
+		r, ok := intRanges[t.Name]
+		if !ok {
+			return fmt.Errorf("unsupported type %s", t.Name)
+		}
+		v := g.name("n")
+		g.Print("%s, err := blizzval.AsInt(%s, %s, %s)", v, src, r[0], r[1])
+		g.fail(p)
+		switch t.Name {
+		case "int64":
+			g.Print("%s = %s", dst, v)
+		case "bool":
+			g.Print("%s = %s != 0", dst, v)
+		default:
+			g.Print("%s = %s(%s)", dst, t.Name, v)
+		}
+	case *ast.SelectorExpr:
+		if !isValue(t) {
+			return fmt.Errorf("unsupported type %s", typeString(t))
+		}
+		g.Print("%s = %s", dst, src)
+	case *ast.StarExpr:
+		g.Print("if %s != nil {", src)
+		if id, ok := t.X.(*ast.Ident); ok && g.structs[id.Name] {
+			v := g.name("p")
+			g.Print("%s, err := read%s(%s)", v, id.Name, src)
+			g.fail(p)
+			g.Print("%s = %s", dst, v)
+		} else {
+			v := g.name("v")
+			g.Print("var %s %s", v, typeString(t.X))
+			if err := g.decode(t.X, src, v, p); err != nil {
+				return err
+			}
+			g.Print("%s = &%s", dst, v)
+		}
+		g.Print("}")
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return fmt.Errorf("unsupported array type %s", typeString(t))
+		}
+		if id, ok := t.Elt.(*ast.Ident); ok && (id.Name == "byte" || id.Name == "uint8") {
+			v := g.name("b")
+			g.Print("%s, err := blizzval.AsBytes(%s)", v, src)
+			g.fail(p)
+			g.Print("%s = %s", dst, v)
+			return nil
+		}
+		s, i, e := g.name("s"), g.name("i"), g.name("e")
+		g.Print("%s, err := blizzval.AsArray(%s)", s, src)
+		g.fail(p)
+		g.Print("%s = make(%s, len(%s))", dst, typeString(t), s)
+		g.Print("for %s, %s := range %s {", i, e, s)
+		if err := g.decode(t.Elt, e, dst+"["+i+"]", p.index(i)); err != nil {
+			return err
+		}
+		g.Print("}")
+	default:
+		return fmt.Errorf("unsupported type %s", typeString(t))
+	}
+	return nil
+}
+
+// encode emits code encoding src, of type t, into the Value dst.
+func (g *gen) encode(t ast.Expr, src, dst string) {
+	switch t := t.(type) {
+	case *ast.Ident:
+		switch {
+		case g.structs[t.Name]:
+			g.Print("%s = encode%s(&%s)", dst, t.Name, src)
+		case t.Name == "string", t.Name == "int64", t.Name == "uint8",
+			t.Name == "byte", t.Name == "uint32", t.Name == "uint64":
+			g.Print("%s = %s", dst, src)
+		case t.Name == "bool":
+			g.Print("if %s {", src)
+			g.Print("%s = uint8(1)", dst)
+			g.Print("} else {")
+			g.Print("%s = uint8(0)", dst)
+			g.Print("}")
+		default:
+			g.Print("%s = int64(%s)", dst, src)
+		}
+	case *ast.SelectorExpr:
+		g.Print("%s = %s", dst, src)
+	case *ast.StarExpr:
+		g.Print("if %s != nil {", src)
+		if id, ok := t.X.(*ast.Ident); ok && g.structs[id.Name] {
+			g.Print("%s = encode%s(%s)", dst, id.Name, src)
+		} else {
+			g.encode(t.X, "*"+src, dst)
+		}
+		g.Print("}")
+	case *ast.ArrayType:
+		if id, ok := t.Elt.(*ast.Ident); ok && (id.Name == "byte" || id.Name == "uint8") {
+			g.Print("%s = string(%s)", dst, src)
+			return
+		}
+		s, i, e := g.name("s"), g.name("i"), g.name("e")
+		g.Print("%s := make([]blizzval.Value, len(%s))", s, src)
+		g.Print("for %s, %s := range %s {", i, e, src)
+		g.encode(t.Elt, e, s+"["+i+"]")
+		g.Print("}")
+		g.Print("%s = %s", dst, s)
+	}
+}
 
-	ps := read(*inf)
-	w := w{os.Stdout}
-	w.Print("package replay")
-	w.Print("import \"blizzard/blizzval\"")
+func generate(pkg string, ps []*protoStruct) ([]byte, error) {
+	g := gen{structs: map[string]bool{}}
 	for _, p := range ps {
-		log.Printf("%s", p.Name)
-		w.Print("func read%s(v blizzval.Value) *%s {", p.Name, p.Name)
-		w.Print("out := %s{}", p.Name)
-		w.Print("out.Raw = v")
-		w.Print("m := v.(map[int]blizzval.Value)")
+		g.structs[p.Name] = true
+	}
+
+	for _, p := range ps {
+		g.Print("func read%s(v blizzval.Value) (*%s, error) {", p.Name, p.Name)
+		g.Print("m, err := blizzval.AsMap(v)")
+		g.fail(path{format: p.Name})
+		g.Print("out := &%s{}", p.Name)
+		if p.Raw {
+			g.Print("out.Raw = v")
+		}
+		for _, f := range p.Fields {
+			fp := path{format: p.Name + "." + f.Name}
+			g.Print("if f, ok := m[%d]; ok {", f.Key)
+			if err := g.decode(f.Type, "f", "out."+f.Name, fp); err != nil {
+				return nil, fmt.Errorf("%s.%s: %s", p.Name, f.Name, err)
+			}
+			if !f.OmitEmpty {
+				g.Print("} else {")
+				g.Print("return nil, &blizzval.UnmarshalError{Path: %s, Msg: \"missing key %d\"}", fp.expr(), f.Key)
+			}
+			g.Print("}")
+		}
+		g.Print("return out, nil")
+		g.Print("}")
+		g.Print("")
+
+		g.Print("func encode%s(in *%s) blizzval.Value {", p.Name, p.Name)
+		g.Print("m := map[int]blizzval.Value{}")
+		if p.Raw {
+			g.Print("if raw, ok := in.Raw.(map[int]blizzval.Value); ok {")
+			g.Print("for k, v := range raw {")
+			g.Print("m[k] = v")
+			g.Print("}")
+			g.Print("}")
+		}
 		for _, f := range p.Fields {
-			if f.Slice {
-				w.Print("if f, ok := m[%d]; ok {", f.Key)
-				w.Print("s := f.([]blizzval.Value)")
-				w.Print("out.%s = make([]*%s, len(s))", f.Name, f.Type)
-				w.Print("for i := 0; i < len(s); i++ {")
-				w.Print("out.%s[i] = read%s(s[i])", f.Name, f.Type)
-				w.Print("}")
-				w.Print("}")
-			} else {
-				w.Print("out.%s = m[%d].(%s)", f.Name, f.Key, f.Type)
+			dst := fmt.Sprintf("m[%d]", f.Key)
+			if _, ok := f.Type.(*ast.StarExpr); ok && !f.OmitEmpty {
+				g.Print("%s = nil", dst)
 			}
+			if f.OmitEmpty && isValue(f.Type) {
+				g.Print("if in.%s != nil {", f.Name)
+				g.encode(f.Type, "in."+f.Name, dst)
+				g.Print("}")
+				continue
+			}
+			g.encode(f.Type, "in."+f.Name, dst)
 		}
-		w.Print("return &out")
-		w.Print("}")
+		g.Print("return m")
+		g.Print("}")
+		g.Print("")
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by blizzval/gen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", pkg)
+	if g.usesFmt {
+		fmt.Fprintf(&out, "import (\n\"fmt\"\n\n\"blizzard/blizzval\"\n)\n\n")
+	} else {
+		fmt.Fprintf(&out, "import \"blizzard/blizzval\"\n\n")
+	}
+	out.Write(g.Bytes())
+	return format.Source(out.Bytes())
+}
+
+func main() {
+	inf := flag.String("in", "", "input filename")
+	outf := flag.String("out", "", "output filename (default stdout)")
+	flag.Parse()
+
+	pkg, ps, err := read(*inf)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(pkg, ps)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *outf == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*outf, src, 0666); err != nil {
+		log.Fatal(err)
 	}
 }
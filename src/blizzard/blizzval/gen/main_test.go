@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// TestGenerated checks that the checked-in generated files are up to
+// date with the generator.
+func TestGenerated(t *testing.T) {
+	for _, test := range []struct {
+		in, out string
+	}{
+		{"internal/gentest/types.go", "internal/gentest/types_proto.go"},
+	} {
+		pkg, ps, err := read(test.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		src, err := generate(pkg, ps)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp, err := os.ReadFile(test.out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(src, exp) {
+			t.Errorf("%s is out of date; run go generate", test.out)
+		}
+	}
+}
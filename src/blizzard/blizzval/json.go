@@ -0,0 +1,289 @@
+package blizzval
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// JSONOptions controls conversion between Values and JSON.
+type JSONOptions struct {
+	// TypeHints marks values whose wire type JSON cannot express, so
+	// that FromJSON can restore them exactly.  u8, u32 and u64 integers
+	// are written as {"$u8": n}, {"$u32": n} and {"$u64": n}, blobs
+	// that are not valid UTF-8 as {"$base64": "..."}, bit arrays as
+	// {"$bits": [len, "base64"]} and choices as {"$choice": [tag,
+	// value]}.  Without hints, integers are plain numbers, binary blobs
+	// and bit arrays are bare base64 strings, and choices are their
+	// values.
+	TypeHints bool
+}
+
+func (o *JSONOptions) typeHints() bool {
+	return o != nil && o.TypeHints
+}
+
+// ToJSON converts v to JSON.  Maps become objects whose keys are the
+// stringified integer keys in ascending numeric order, arrays become
+// arrays, absent optionals become null, and blobs become strings.
+func ToJSON(v Value, opts *JSONOptions) ([]byte, error) {
+	return appendJSON(nil, v, opts)
+}
+
+func appendHint(buf []byte, hint string, val []byte) []byte {
+	buf = append(buf, `{"$`...)
+	buf = append(buf, hint...)
+	buf = append(buf, `":`...)
+	buf = append(buf, val...)
+	return append(buf, '}')
+}
+
+func appendJSON(buf []byte, v Value, opts *JSONOptions) ([]byte, error) {
+	var err error
+	switch v := v.(type) {
+	case []Value:
+		buf = append(buf, '[')
+		for i, elem := range v {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			if buf, err = appendJSON(buf, elem, opts); err != nil {
+				return buf, err
+			}
+		}
+		buf = append(buf, ']')
+	case string:
+		if utf8.ValidString(v) {
+			enc, _ := json.Marshal(v)
+			buf = append(buf, enc...)
+			break
+		}
+		enc := strconv.AppendQuote(nil, base64.StdEncoding.EncodeToString([]byte(v)))
+		if opts.typeHints() {
+			buf = appendHint(buf, "base64", enc)
+		} else {
+			buf = append(buf, enc...)
+		}
+	case nil:
+		buf = append(buf, "null"...)
+	case map[int]Value:
+		keys := make([]int, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Ints(keys)
+		buf = append(buf, '{')
+		for i, k := range keys {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = append(buf, '"')
+			buf = strconv.AppendInt(buf, int64(k), 10)
+			buf = append(buf, `":`...)
+			if buf, err = appendJSON(buf, v[k], opts); err != nil {
+				return buf, err
+			}
+		}
+		buf = append(buf, '}')
+	case uint8:
+		enc := strconv.AppendUint(nil, uint64(v), 10)
+		if opts.typeHints() {
+			buf = appendHint(buf, "u8", enc)
+		} else {
+			buf = append(buf, enc...)
+		}
+	case uint32:
+		enc := strconv.AppendUint(nil, uint64(v), 10)
+		if opts.typeHints() {
+			buf = appendHint(buf, "u32", enc)
+		} else {
+			buf = append(buf, enc...)
+		}
+	case uint64:
+		enc := strconv.AppendUint(nil, v, 10)
+		if opts.typeHints() {
+			buf = appendHint(buf, "u64", enc)
+		} else {
+			buf = append(buf, enc...)
+		}
+	case int64:
+		buf = strconv.AppendInt(buf, v, 10)
+	case BitArray:
+		enc := strconv.AppendQuote(nil, base64.StdEncoding.EncodeToString(v.Bytes))
+		if opts.typeHints() {
+			val := append(strconv.AppendInt([]byte{'['}, int64(v.Len), 10), ',')
+			buf = appendHint(buf, "bits", append(append(val, enc...), ']'))
+		} else {
+			buf = append(buf, enc...)
+		}
+	case Variant:
+		if !opts.typeHints() {
+			return appendJSON(buf, v.Value, opts)
+		}
+		val := append(strconv.AppendInt([]byte{'['}, int64(v.Tag), 10), ',')
+		if val, err = appendJSON(val, v.Value, opts); err != nil {
+			return buf, err
+		}
+		buf = appendHint(buf, "choice", append(val, ']'))
+	case TypedValue:
+		return appendJSON(buf, Untyped(v), opts)
+	default:
+		return buf, fmt.Errorf("blizzval: cannot convert %T to JSON", v)
+	}
+	return buf, nil
+}
+
+// FromJSON converts JSON produced by ToJSON back to a Value.  Numbers
+// become varints and strings become blobs, unless opts.TypeHints is
+// set and the JSON carries hints recording other types.
+func FromJSON(data []byte, opts *JSONOptions) (Value, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var j interface{}
+	if err := d.Decode(&j); err != nil {
+		return nil, err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, fmt.Errorf("blizzval: trailing data after JSON value")
+	}
+	return fromJSON(j, opts)
+}
+
+func fromJSON(j interface{}, opts *JSONOptions) (Value, error) {
+	switch j := j.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return j, nil
+	case json.Number:
+		n, err := strconv.ParseInt(string(j), 10, 64)
+		if err != nil {
+			// Unhinted u64 values may not fit in a varint.
+			u, uerr := strconv.ParseUint(string(j), 10, 64)
+			if uerr != nil {
+				return nil, fmt.Errorf("blizzval: bad JSON integer %s", j)
+			}
+			return u, nil
+		}
+		return n, nil
+	case []interface{}:
+		arr := make([]Value, len(j))
+		for i, elem := range j {
+			val, err := fromJSON(elem, opts)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = val
+		}
+		return arr, nil
+	case map[string]interface{}:
+		if opts.typeHints() && len(j) == 1 {
+			for k, elem := range j {
+				if len(k) > 0 && k[0] == '$' {
+					return fromHint(k[1:], elem, opts)
+				}
+			}
+		}
+		m := map[int]Value{}
+		for k, elem := range j {
+			key, err := strconv.Atoi(k)
+			if err != nil {
+				return nil, fmt.Errorf("blizzval: JSON object key %q is not an integer", k)
+			}
+			val, err := fromJSON(elem, opts)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = val
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("blizzval: cannot convert JSON %T", j)
+}
+
+func fromHint(hint string, j interface{}, opts *JSONOptions) (Value, error) {
+	switch hint {
+	case "u8", "u32", "u64":
+		n, ok := j.(json.Number)
+		if !ok {
+			break
+		}
+		bits, _ := strconv.Atoi(hint[1:])
+		u, err := strconv.ParseUint(string(n), 10, bits)
+		if err != nil {
+			return nil, fmt.Errorf("blizzval: bad $%s value %s", hint, n)
+		}
+		switch bits {
+		case 8:
+			return uint8(u), nil
+		case 32:
+			return uint32(u), nil
+		}
+		return u, nil
+	case "bits", "choice":
+		pair, ok := j.([]interface{})
+		if !ok || len(pair) != 2 {
+			break
+		}
+		n, ok := pair[0].(json.Number)
+		if !ok {
+			break
+		}
+		i, err := strconv.Atoi(string(n))
+		if err != nil {
+			return nil, fmt.Errorf("blizzval: bad $%s value %s", hint, n)
+		}
+		if hint == "choice" {
+			val, err := fromJSON(pair[1], opts)
+			if err != nil {
+				return nil, err
+			}
+			return Variant{i, val}, nil
+		}
+		s, ok := pair[1].(string)
+		if !ok {
+			break
+		}
+		buf, err := base64.StdEncoding.DecodeString(s)
+		if err != nil || i < 0 || len(buf) != (i+7)/8 {
+			return nil, fmt.Errorf("blizzval: bad $bits value")
+		}
+		return BitArray{i, buf}, nil
+	case "base64":
+		s, ok := j.(string)
+		if !ok {
+			break
+		}
+		buf, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("blizzval: bad $base64 value: %s", err)
+		}
+		return string(buf), nil
+	}
+	return nil, fmt.Errorf("blizzval: bad JSON type hint $%s", hint)
+}
+
+// JSON wraps a Value to implement json.Marshaler and json.Unmarshaler,
+// so that values can be embedded in larger JSON documents.
+type JSON struct {
+	Value   Value
+	Options *JSONOptions
+}
+
+func (j JSON) MarshalJSON() ([]byte, error) {
+	return ToJSON(j.Value, j.Options)
+}
+
+func (j *JSON) UnmarshalJSON(data []byte) error {
+	v, err := FromJSON(data, j.Options)
+	if err != nil {
+		return err
+	}
+	j.Value = v
+	return nil
+}
@@ -0,0 +1,77 @@
+package blizzval
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"testing/quick"
+)
+
+func TestToJSON(t *testing.T) {
+	v := map[int]Value{
+		10: []Value{uint8(1), uint32(2), int64(-3), nil},
+		2:  "text",
+		0:  "\xff\x00",
+		11: []Value{uint64(4), BitArray{10, []byte{0x02, 0x01}}, Variant{1, int64(2)}},
+	}
+	for _, test := range []struct {
+		opts *JSONOptions
+		exp  string
+	}{
+		{nil, `{"0":"/wA=","2":"text","10":[1,2,-3,null],"11":[4,"AgE=",2]}`},
+		{&JSONOptions{TypeHints: true}, `{"0":{"$base64":"/wA="},"2":"text","10":[{"$u8":1},{"$u32":2},-3,null],` +
+			`"11":[{"$u64":4},{"$bits":[10,"AgE="]},{"$choice":[1,2]}]}`},
+	} {
+		j, err := ToJSON(v, test.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(j) != test.exp {
+			t.Errorf("got %s, expected %s", j, test.exp)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	opts := &JSONOptions{TypeHints: true}
+	roundTrip := func(rv randValue) bool {
+		var exp, got bytes.Buffer
+		Encode(&exp, rv.v)
+
+		j, err := ToJSON(rv.v, opts)
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		v, err := FromJSON(j, opts)
+		if err != nil {
+			t.Errorf("%s: %s", j, err)
+			return false
+		}
+		Encode(&got, v)
+		return bytes.Equal(got.Bytes(), exp.Bytes())
+	}
+	if err := quick.Check(roundTrip, &quick.Config{MaxCount: 2000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestJSONWrapper(t *testing.T) {
+	doc := struct {
+		Details JSON `json:"details"`
+	}{JSON{Value: map[int]Value{1: "map"}}}
+	j, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(j) != `{"details":{"1":"map"}}` {
+		t.Errorf("unexpected JSON %s", j)
+	}
+
+	if err := json.Unmarshal([]byte(`{"details":[1,"x"]}`), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if arr, ok := doc.Details.Value.([]Value); !ok || len(arr) != 2 || arr[0] != int64(1) || arr[1] != "x" {
+		t.Errorf("unexpected value %#v", doc.Details.Value)
+	}
+}
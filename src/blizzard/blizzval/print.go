@@ -0,0 +1,401 @@
+package blizzval
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// BlobStyle selects how Fprint renders blobs.
+type BlobStyle int
+
+const (
+	BlobQuoted BlobStyle = iota // a Go-quoted string: "abc"
+	BlobHex                     // hex digits: x"616263"
+)
+
+// PrintOptions controls the output of Fprint.  The zero value prints
+// decimal integers and quoted blobs, indented one entry per line.
+type PrintOptions struct {
+	Hex   bool      // print integers in hexadecimal
+	Blobs BlobStyle // how to render blobs
+
+	// MaxBlobLen, if positive, truncates longer blobs to that many
+	// bytes, followed by "...".
+	MaxBlobLen int
+
+	// MaxDepth, if positive, elides arrays and maps nested deeper than
+	// that as [...] and {...}.
+	MaxDepth int
+
+	// Compact prints the whole value on a single line.
+	Compact bool
+}
+
+// Fprint writes a textual representation of v to w.  Maps are written
+// as {key: value ...} in ascending key order and arrays as [value ...].
+// Varints are written as plain integers, u8, u32 and u64 values as
+// u8(n), u32(n) and u64(n), bit arrays as bits(len, x"bytes"), choices
+// as choice(tag, value) and absent optionals as nil.  Unless blobs are
+// truncated or values elided, the output can be read back with Parse.
+//
+// v may also be a TypedValue, which is printed as its Untyped form.
+func Fprint(w io.Writer, v Value, opts *PrintOptions) error {
+	p := printer{w: bufio.NewWriter(w)}
+	if opts != nil {
+		p.PrintOptions = *opts
+	}
+	if err := p.print(v, 0); err != nil {
+		return err
+	}
+	return p.w.Flush()
+}
+
+type printer struct {
+	PrintOptions
+	w *bufio.Writer
+}
+
+func (p *printer) int(val int64) {
+	if p.Hex {
+		fmt.Fprintf(p.w, "%#x", val)
+	} else {
+		p.w.WriteString(strconv.FormatInt(val, 10))
+	}
+}
+
+func (p *printer) blob(s string) {
+	truncated := p.MaxBlobLen > 0 && len(s) > p.MaxBlobLen
+	if truncated {
+		s = s[:p.MaxBlobLen]
+	}
+	if p.Blobs == BlobHex {
+		p.w.WriteString(`x"` + hex.EncodeToString([]byte(s)) + `"`)
+	} else {
+		p.w.WriteString(strconv.Quote(s))
+	}
+	if truncated {
+		p.w.WriteString("...")
+	}
+}
+
+// entry starts the ith entry of a container, indenting it as needed.
+func (p *printer) entry(i, depth int) {
+	if p.Compact {
+		if i > 0 {
+			p.w.WriteString(", ")
+		}
+		return
+	}
+	p.w.WriteString("\n")
+	p.w.WriteString(strings.Repeat("  ", depth+1))
+}
+
+// close ends a container of n entries.
+func (p *printer) close(n, depth int, c byte) {
+	if !p.Compact && n > 0 {
+		p.w.WriteString("\n")
+		p.w.WriteString(strings.Repeat("  ", depth))
+	}
+	p.w.WriteByte(c)
+}
+
+func (p *printer) print(v Value, depth int) error {
+	switch v := v.(type) {
+	case map[int]Value:
+		if p.MaxDepth > 0 && depth >= p.MaxDepth {
+			p.w.WriteString("{...}")
+			return nil
+		}
+		keys := make([]int, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Ints(keys)
+		p.w.WriteByte('{')
+		for i, k := range keys {
+			p.entry(i, depth)
+			fmt.Fprintf(p.w, "%d: ", k)
+			if err := p.print(v[k], depth+1); err != nil {
+				return err
+			}
+		}
+		p.close(len(keys), depth, '}')
+	case []Value:
+		if p.MaxDepth > 0 && depth >= p.MaxDepth {
+			p.w.WriteString("[...]")
+			return nil
+		}
+		p.w.WriteByte('[')
+		for i, elem := range v {
+			p.entry(i, depth)
+			if err := p.print(elem, depth+1); err != nil {
+				return err
+			}
+		}
+		p.close(len(v), depth, ']')
+	case string:
+		p.blob(v)
+	case uint8:
+		p.w.WriteString("u8(")
+		p.int(int64(v))
+		p.w.WriteByte(')')
+	case uint32:
+		p.w.WriteString("u32(")
+		p.int(int64(v))
+		p.w.WriteByte(')')
+	case uint64:
+		p.w.WriteString("u64(")
+		if p.Hex {
+			fmt.Fprintf(p.w, "%#x", v)
+		} else {
+			p.w.WriteString(strconv.FormatUint(v, 10))
+		}
+		p.w.WriteByte(')')
+	case int64:
+		p.int(v)
+	case nil:
+		p.w.WriteString("nil")
+	case BitArray:
+		fmt.Fprintf(p.w, "bits(%d, x\"%x\")", v.Len, v.Bytes)
+	case Variant:
+		p.w.WriteString("choice(")
+		p.int(int64(v.Tag))
+		p.w.WriteString(", ")
+		if err := p.print(v.Value, depth); err != nil {
+			return err
+		}
+		p.w.WriteByte(')')
+	case TypedValue:
+		return p.print(Untyped(v), depth)
+	default:
+		return fmt.Errorf("blizzval: cannot print %T", v)
+	}
+	return nil
+}
+
+// Parse reads a value in the format written by Fprint, in any of its
+// styles.  Truncated blobs and elided values are errors.
+func Parse(s string) (Value, error) {
+	p := &parser{s: s}
+	v, err := p.value(0)
+	if err == nil {
+		p.space()
+		if p.i < len(p.s) {
+			err = p.errorf("unexpected %q after value", p.s[p.i])
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+type parser struct {
+	s string
+	i int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("blizzval: parse error at offset %d: %s", p.i, fmt.Sprintf(format, args...))
+}
+
+// space skips whitespace.
+func (p *parser) space() {
+	for p.i < len(p.s) && unicode.IsSpace(rune(p.s[p.i])) {
+		p.i++
+	}
+}
+
+// sep skips whitespace and the commas separating compact entries.
+func (p *parser) sep() {
+	for p.i < len(p.s) && (p.s[p.i] == ',' || unicode.IsSpace(rune(p.s[p.i]))) {
+		p.i++
+	}
+}
+
+func (p *parser) consume(prefix string) bool {
+	if strings.HasPrefix(p.s[p.i:], prefix) {
+		p.i += len(prefix)
+		return true
+	}
+	return false
+}
+
+// word returns the run of characters that can make up an integer.
+func (p *parser) word() string {
+	start := p.i
+	for p.i < len(p.s) && (p.s[p.i] == '-' || p.s[p.i] == '+' ||
+		unicode.IsLetter(rune(p.s[p.i])) || unicode.IsDigit(rune(p.s[p.i]))) {
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+func (p *parser) uint(bits int) (uint64, error) {
+	w := p.word()
+	n, err := strconv.ParseUint(w, 0, bits)
+	if err != nil {
+		return 0, p.errorf("bad u%d %q", bits, w)
+	}
+	if !p.consume(")") {
+		return 0, p.errorf("expected )")
+	}
+	return n, nil
+}
+
+// bits parses the rest of bits(len, x"bytes").
+func (p *parser) bits() (Value, error) {
+	p.space()
+	w := p.word()
+	n, err := strconv.ParseInt(w, 0, 0)
+	if err != nil || n < 0 || n > MaxLength {
+		return nil, p.errorf("bad bit array length %q", w)
+	}
+	p.space()
+	if !p.consume(",") {
+		return nil, p.errorf("expected ,")
+	}
+	p.space()
+	if !p.consume(`x`) || p.i == len(p.s) || p.s[p.i] != '"' {
+		return nil, p.errorf("expected hex bytes")
+	}
+	s, err := p.str()
+	if err != nil {
+		return nil, err
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != (int(n)+7)/8 {
+		return nil, p.errorf("bad bit array bytes")
+	}
+	p.space()
+	if !p.consume(")") {
+		return nil, p.errorf("expected )")
+	}
+	return BitArray{int(n), b}, nil
+}
+
+// choice parses the rest of choice(tag, value).
+func (p *parser) choice(depth int) (Value, error) {
+	p.space()
+	w := p.word()
+	tag, err := strconv.ParseInt(w, 0, 0)
+	if err != nil {
+		return nil, p.errorf("bad choice tag %q", w)
+	}
+	p.space()
+	if !p.consume(",") {
+		return nil, p.errorf("expected ,")
+	}
+	v, err := p.value(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+	p.space()
+	if !p.consume(")") {
+		return nil, p.errorf("expected )")
+	}
+	return Variant{int(tag), v}, nil
+}
+
+func (p *parser) str() (string, error) {
+	q, err := strconv.QuotedPrefix(p.s[p.i:])
+	if err != nil {
+		return "", p.errorf("bad string")
+	}
+	p.i += len(q)
+	s, err := strconv.Unquote(q)
+	if err != nil {
+		return "", p.errorf("bad string")
+	}
+	if strings.HasPrefix(p.s[p.i:], "...") {
+		return "", p.errorf("truncated blob")
+	}
+	return s, nil
+}
+
+func (p *parser) value(depth int) (Value, error) {
+	if depth > MaxDepth {
+		return nil, p.errorf("%s", errTooDeep)
+	}
+	p.space()
+	switch {
+	case p.i == len(p.s):
+		return nil, p.errorf("unexpected end of input")
+	case p.consume("[...]"), p.consume("{...}"):
+		return nil, p.errorf("elided value")
+	case p.consume("["):
+		arr := []Value{}
+		for {
+			p.sep()
+			if p.consume("]") {
+				return arr, nil
+			}
+			v, err := p.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+	case p.consume("{"):
+		m := map[int]Value{}
+		for {
+			p.sep()
+			if p.consume("}") {
+				return m, nil
+			}
+			if p.i == len(p.s) {
+				return nil, p.errorf("unexpected end of input")
+			}
+			w := p.word()
+			key, err := strconv.ParseInt(w, 0, 0)
+			if err != nil {
+				return nil, p.errorf("bad map key %q", w)
+			}
+			if !p.consume(":") {
+				return nil, p.errorf("expected :")
+			}
+			if m[int(key)], err = p.value(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+	case p.s[p.i] == '"':
+		return p.str()
+	case p.consume(`x"`):
+		p.i-- // leave the quote for str
+		s, err := p.str()
+		if err != nil {
+			return nil, err
+		}
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, p.errorf("bad hex blob")
+		}
+		return string(b), nil
+	case p.consume("nil"):
+		return nil, nil
+	case p.consume("u8("):
+		n, err := p.uint(8)
+		return uint8(n), err
+	case p.consume("u32("):
+		n, err := p.uint(32)
+		return uint32(n), err
+	case p.consume("u64("):
+		return p.uint(64)
+	case p.consume("bits("):
+		return p.bits()
+	case p.consume("choice("):
+		return p.choice(depth)
+	}
+	w := p.word()
+	n, err := strconv.ParseInt(w, 0, 64)
+	if err != nil {
+		return nil, p.errorf("unexpected %q", w)
+	}
+	return n, nil
+}
@@ -0,0 +1,101 @@
+package blizzval
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+var printTestValue = map[int]Value{
+	0: []Value{int64(-3), uint8(10), uint32(255)},
+	1: "ab\n",
+	2: nil,
+	3: map[int]Value{},
+	4: []Value{[]Value{}},
+}
+
+func TestFprint(t *testing.T) {
+	for _, test := range []struct {
+		opts PrintOptions
+		exp  string
+	}{
+		{PrintOptions{}, `{
+  0: [
+    -3
+    u8(10)
+    u32(255)
+  ]
+  1: "ab\n"
+  2: nil
+  3: {}
+  4: [
+    []
+  ]
+}`},
+		{PrintOptions{Compact: true}, `{0: [-3, u8(10), u32(255)], 1: "ab\n", 2: nil, 3: {}, 4: [[]]}`},
+		{PrintOptions{Compact: true, Hex: true, Blobs: BlobHex},
+			`{0: [-0x3, u8(0xa), u32(0xff)], 1: x"61620a", 2: nil, 3: {}, 4: [[]]}`},
+		{PrintOptions{Compact: true, MaxBlobLen: 1, MaxDepth: 1},
+			`{0: [...], 1: "a"..., 2: nil, 3: {...}, 4: [...]}`},
+	} {
+		var buf bytes.Buffer
+		if err := Fprint(&buf, printTestValue, &test.opts); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != test.exp {
+			t.Errorf("%+v: got\n%s\nexpected\n%s", test.opts, buf.String(), test.exp)
+		}
+	}
+
+	if err := Fprint(&bytes.Buffer{}, []Value{3}, nil); err == nil {
+		t.Errorf("printing an int succeeded")
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	for _, opts := range []PrintOptions{
+		{},
+		{Compact: true},
+		{Hex: true, Blobs: BlobHex},
+		{Compact: true, Hex: true},
+	} {
+		check := func(rv randValue) bool {
+			var buf bytes.Buffer
+			if err := Fprint(&buf, rv.v, &opts); err != nil {
+				t.Error(err)
+				return false
+			}
+			val, err := Parse(buf.String())
+			if err != nil {
+				t.Errorf("%+v: %s\n%s", opts, err, buf.String())
+				return false
+			}
+			return reflect.DeepEqual(val, rv.v)
+		}
+		if err := quick.Check(check, &quick.Config{MaxCount: 500}); err != nil {
+			t.Errorf("%+v: %s", opts, err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, test := range []struct {
+		in, exp string
+	}{
+		{`"ab"...`, "truncated blob"},
+		{`[1, [...]]`, "elided value"},
+		{`{1: 2`, "unexpected end of input"},
+		{`u8(256)`, "bad u8"},
+		{`x"6"`, "bad hex blob"},
+		{`{a: 1}`, "bad map key"},
+		{`1 2`, "after value"},
+		{`foo`, `unexpected "foo"`},
+	} {
+		_, err := Parse(test.in)
+		if err == nil || !strings.Contains(err.Error(), test.exp) {
+			t.Errorf("%s: got error %v, expected %q", test.in, err, test.exp)
+		}
+	}
+}
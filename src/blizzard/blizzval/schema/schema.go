@@ -0,0 +1,373 @@
+// Package schema infers the structure of a corpus of blizzval values,
+// to aid reverse engineering their meaning.
+package schema
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"blizzard/blizzval"
+)
+
+// MaxExamples is the number of distinct example values kept per path.
+const MaxExamples = 3
+
+// Node describes the values observed at one path.
+type Node struct {
+	Path  string         // such as ".0[].5"; the root is ""
+	Count int            // values observed
+	Types map[string]int // count of each wire type
+
+	// MinInt and MaxInt give the range of integer values.
+	MinInt, MaxInt int64
+	// MinLen and MaxLen give the range of array, map and blob lengths.
+	MinLen, MaxLen int
+	Binary         bool // a blob was not valid UTF-8
+
+	Examples []string // distinct values, printed compactly
+
+	Keys map[int]*Node // entries of maps
+	Elem *Node         // elements of arrays
+}
+
+func newNode(path string) *Node {
+	return &Node{Path: path, Types: map[string]int{}}
+}
+
+func wireType(v blizzval.Value) string {
+	switch v.(type) {
+	case []blizzval.Value:
+		return "array"
+	case string:
+		return "blob"
+	case nil:
+		return "nil"
+	case map[int]blizzval.Value:
+		return "map"
+	case uint8:
+		return "u8"
+	case uint32:
+		return "u32"
+	case uint64:
+		return "u64"
+	case int64:
+		return "varint"
+	case blizzval.BitArray:
+		return "bit array"
+	case blizzval.Variant:
+		return "choice"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func (n *Node) hasInts() bool {
+	return n.Types["u8"]+n.Types["u32"]+n.Types["varint"] > 0
+}
+
+func (n *Node) hasLens() bool {
+	return n.Types["array"]+n.Types["map"]+n.Types["blob"] > 0
+}
+
+func (n *Node) addInt(i int64) {
+	if !n.hasInts() || i < n.MinInt {
+		n.MinInt = i
+	}
+	if !n.hasInts() || i > n.MaxInt {
+		n.MaxInt = i
+	}
+}
+
+func (n *Node) addLen(l int) {
+	if !n.hasLens() || l < n.MinLen {
+		n.MinLen = l
+	}
+	if !n.hasLens() || l > n.MaxLen {
+		n.MaxLen = l
+	}
+}
+
+var exampleOptions = &blizzval.PrintOptions{Compact: true, MaxBlobLen: 24, MaxDepth: 1}
+
+func (n *Node) addExample(v blizzval.Value) {
+	if len(n.Examples) >= MaxExamples {
+		return
+	}
+	var buf bytes.Buffer
+	if blizzval.Fprint(&buf, v, exampleOptions) != nil {
+		return
+	}
+	for _, e := range n.Examples {
+		if e == buf.String() {
+			return
+		}
+	}
+	n.Examples = append(n.Examples, buf.String())
+}
+
+func (n *Node) add(v blizzval.Value) {
+	// Ranges are updated before Types, which tells them whether this
+	// is the first value of its kind.
+	switch v := v.(type) {
+	case []blizzval.Value:
+		n.addLen(len(v))
+		if n.Elem == nil {
+			n.Elem = newNode(n.Path + "[]")
+		}
+		for _, elem := range v {
+			n.Elem.add(elem)
+		}
+	case map[int]blizzval.Value:
+		n.addLen(len(v))
+		if n.Keys == nil {
+			n.Keys = map[int]*Node{}
+		}
+		for k, elem := range v {
+			child := n.Keys[k]
+			if child == nil {
+				child = newNode(fmt.Sprintf("%s.%d", n.Path, k))
+				n.Keys[k] = child
+			}
+			child.add(elem)
+		}
+	case string:
+		n.addLen(len(v))
+		if !utf8.ValidString(v) {
+			n.Binary = true
+		}
+		n.addExample(v)
+	case uint8:
+		n.addInt(int64(v))
+		n.addExample(v)
+	case uint32:
+		n.addInt(int64(v))
+		n.addExample(v)
+	case int64:
+		n.addInt(v)
+		n.addExample(v)
+	case uint64, blizzval.BitArray, blizzval.Variant:
+		// u64 values may not fit the int64 ranges.
+		n.addExample(v)
+	}
+	n.Count++
+	n.Types[wireType(v)]++
+}
+
+// sortedKeys returns the keys of a map node in ascending order.
+func (n *Node) sortedKeys() []int {
+	keys := make([]int, 0, len(n.Keys))
+	for k := range n.Keys {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// typeSummary describes the wire types observed, most common first.
+func (n *Node) typeSummary() string {
+	types := make([]string, 0, len(n.Types))
+	for t := range n.Types {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if n.Types[types[i]] != n.Types[types[j]] {
+			return n.Types[types[i]] > n.Types[types[j]]
+		}
+		return types[i] < types[j]
+	})
+	if len(types) == 1 {
+		return types[0]
+	}
+	for i, t := range types {
+		types[i] = fmt.Sprintf("%s(%d)", t, n.Types[t])
+	}
+	return strings.Join(types, " ")
+}
+
+// rangeSummary describes the ranges of values observed.
+func (n *Node) rangeSummary() string {
+	var parts []string
+	if n.hasInts() {
+		if n.MinInt == n.MaxInt {
+			parts = append(parts, fmt.Sprintf("= %d", n.MinInt))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d..%d", n.MinInt, n.MaxInt))
+		}
+	}
+	if n.hasLens() {
+		parts = append(parts, fmt.Sprintf("len %d..%d", n.MinLen, n.MaxLen))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Schema accumulates the structure of values added to it.
+type Schema struct {
+	Root *Node
+}
+
+// New returns an empty Schema.
+func New() *Schema {
+	return &Schema{Root: newNode("")}
+}
+
+// Add records the structure of v.
+func (s *Schema) Add(v blizzval.Value) {
+	s.Root.add(v)
+}
+
+// Walk calls fn for each node, parents before children and map
+// entries in ascending key order.  parent is nil for the root.
+func (s *Schema) Walk(fn func(n, parent *Node)) {
+	var walk func(n, parent *Node)
+	walk = func(n, parent *Node) {
+		fn(n, parent)
+		for _, k := range n.sortedKeys() {
+			walk(n.Keys[k], n)
+		}
+		if n.Elem != nil {
+			walk(n.Elem, n)
+		}
+	}
+	walk(s.Root, nil)
+}
+
+// presence returns the fraction of parent maps in which n appeared, or
+// -1 if n is not a map entry.
+func presence(n, parent *Node) float64 {
+	if parent == nil || n == parent.Elem || parent.Types["map"] == 0 {
+		return -1
+	}
+	return float64(n.Count) / float64(parent.Types["map"])
+}
+
+// Report writes a table of every path with its types, presence,
+// ranges and examples.
+func (s *Schema) Report(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "path\tcount\tpresent\ttypes\trange\texamples\n")
+	s.Walk(func(n, parent *Node) {
+		path := n.Path
+		if path == "" {
+			path = "."
+		}
+		present := ""
+		if p := presence(n, parent); p >= 0 {
+			present = fmt.Sprintf("%.0f%%", 100*p)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\n", path, n.Count, present,
+			n.typeSummary(), n.rangeSummary(), strings.Join(n.Examples, ", "))
+	})
+	return tw.Flush()
+}
+
+// goStructs generates Go struct declarations for map nodes.
+type goStructs struct {
+	bytes.Buffer
+	pending []pendingStruct
+}
+
+type pendingStruct struct {
+	name string
+	n    *Node
+}
+
+// goType returns the Go type for values at n, queueing any struct
+// types it refers to under names derived from name.
+func (g *goStructs) goType(n *Node, name string) string {
+	types := 0
+	for t := range n.Types {
+		if t != "nil" {
+			types++
+		}
+	}
+	var typ string
+	switch {
+	case types != 1 && !(n.hasInts() && types == n.intTypes()):
+		return "blizzval.Value"
+	case n.Types["map"] > 0:
+		g.pending = append(g.pending, pendingStruct{name, n})
+		return "*" + name
+	case n.Types["array"] > 0:
+		typ = "[]blizzval.Value"
+		if n.Elem != nil {
+			typ = "[]" + g.goType(n.Elem, name+"Elem")
+		}
+	case n.Types["blob"] > 0:
+		typ = "string"
+		if n.Binary {
+			typ = "[]byte"
+		}
+	case n.Types["u8"] > 0 && types == 1:
+		typ = "uint8"
+	case n.Types["u32"] > 0 && types == 1:
+		typ = "uint32"
+	default:
+		typ = "int64"
+	}
+	if n.Types["nil"] > 0 {
+		typ = "*" + typ
+	}
+	return typ
+}
+
+func (n *Node) intTypes() int {
+	types := 0
+	for _, t := range []string{"u8", "u32", "varint"} {
+		if n.Types[t] > 0 {
+			types++
+		}
+	}
+	return types
+}
+
+func (g *goStructs) emit(name string, n *Node) {
+	fmt.Fprintf(g, "type %s struct {\n", name)
+	fmt.Fprintf(g, "Raw blizzval.Value\n")
+	for _, k := range n.sortedKeys() {
+		child := n.Keys[k]
+		field := fmt.Sprintf("Key%d", k)
+		tag := fmt.Sprint(k)
+		if child.Count < n.Types["map"] {
+			tag += ",omitempty"
+		}
+		comment := child.typeSummary()
+		if r := child.rangeSummary(); r != "" {
+			comment += ", " + r
+		}
+		comment += fmt.Sprintf(", present %.0f%%", 100*presence(child, n))
+		if len(child.Examples) > 0 {
+			comment += ", e.g. " + strings.Join(child.Examples, ", ")
+		}
+		fmt.Fprintf(g, "// %s\n", strings.ReplaceAll(comment, "\n", " "))
+		fmt.Fprintf(g, "%s %s `blizz:%q`\n", field, g.goType(child, name+field), tag)
+	}
+	fmt.Fprintf(g, "}\n\n")
+}
+
+// GoStructs writes draft Go struct declarations, suitable for
+// blizzval/gen, describing the values added, which must be maps.  The
+// outermost struct is called name and nested structs are named after
+// their fields.  Fields are named after their keys, and commented with
+// what was observed of them.
+func (s *Schema) GoStructs(w io.Writer, name string) error {
+	if s.Root.Types["map"] == 0 || len(s.Root.Types) != 1 {
+		return fmt.Errorf("schema: values are %s, not maps", s.Root.typeSummary())
+	}
+	g := &goStructs{pending: []pendingStruct{{name, s.Root}}}
+	for len(g.pending) > 0 {
+		p := g.pending[0]
+		g.pending = g.pending[1:]
+		g.emit(p.name, p.n)
+	}
+	src, err := format.Source(g.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"blizzard/blizzval"
+)
+
+func testSchema() *Schema {
+	s := New()
+	s.Add(map[int]blizzval.Value{
+		0: []blizzval.Value{
+			map[int]blizzval.Value{0: "alice", 5: uint8(1)},
+			map[int]blizzval.Value{0: "bob", 5: uint8(2), 7: nil},
+		},
+		1: "map",
+		5: int64(100),
+	})
+	s.Add(map[int]blizzval.Value{
+		0: []blizzval.Value{},
+		1: "\xff\x00",
+		5: int64(-3),
+		6: uint32(9),
+	})
+	return s
+}
+
+func TestReport(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testSchema().Report(&buf); err != nil {
+		t.Fatal(err)
+	}
+	exp := `path    count  present  types   range     examples
+.       2               map     len 3..4  
+.0      2      100%     array   len 0..2  
+.0[]    2               map     len 2..3  
+.0[].0  2      100%     blob    len 3..5  "alice", "bob"
+.0[].5  2      100%     u8      1..2      u8(1), u8(2)
+.0[].7  1      50%      nil               
+.1      2      100%     blob    len 2..3  "map", "\xff\x00"
+.5      2      100%     varint  -3..100   100, -3
+.6      1      50%      u32     = 9       u32(9)
+`
+	if got := buf.String(); got != exp {
+		t.Errorf("got\n%s\nexpected\n%s", got, exp)
+	}
+}
+
+func TestGoStructs(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testSchema().GoStructs(&buf, "Details"); err != nil {
+		t.Fatal(err)
+	}
+	for _, exp := range []string{
+		"type Details struct {",
+		"Key0 []*DetailsKey0Elem `blizz:\"0\"`",
+		"Key1 []byte `blizz:\"1\"`",
+		"Key5 int64 `blizz:\"5\"`",
+		"Key6 uint32 `blizz:\"6,omitempty\"`",
+		"type DetailsKey0Elem struct {",
+		"// u8, 1..2, present 100%, e.g. u8(1), u8(2)\n\tKey5 uint8 `blizz:\"5\"`",
+		"Key7 blizzval.Value `blizz:\"7,omitempty\"`",
+	} {
+		if !strings.Contains(buf.String(), exp) {
+			t.Errorf("output lacks %q:\n%s", exp, buf.String())
+		}
+	}
+
+	s := New()
+	s.Add(int64(1))
+	if err := s.GoStructs(&buf, "X"); err == nil {
+		t.Errorf("generated struct for non-map values")
+	}
+}
@@ -0,0 +1,446 @@
+package blizzval
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// TypedValue is a value decoded with its wire type preserved, unlike
+// Value, which conflates some types.  It is one of Array, BitArray,
+// Blob, Choice, Optional, Struct, U8, U32, U64 or VarInt.
+type TypedValue interface {
+	appendTo(buf []byte) ([]byte, error)
+}
+
+// Array is an array (tag 0x0).
+type Array []TypedValue
+
+// BitArray is an array of Len bits (tag 0x1), held in Bytes padded to
+// whole bytes.
+type BitArray struct {
+	Len   int
+	Bytes []byte
+}
+
+// Blob is a byte string (tag 0x2).
+type Blob []byte
+
+// Choice is a variant of a choice (tag 0x3): the variant's tag and its
+// value.
+type Choice struct {
+	Tag   int
+	Value TypedValue
+}
+
+// Optional is an optional value (tag 0x4); Value is nil if absent.
+type Optional struct {
+	Value TypedValue
+}
+
+// Field is a single entry of a Struct.
+type Field struct {
+	Key   int
+	Value TypedValue
+}
+
+// Struct is a map of integer keys to values (tag 0x5), with its
+// fields kept in the order they were encoded.
+type Struct struct {
+	Fields []Field
+}
+
+// U8 is a single byte integer (tag 0x6).
+type U8 uint8
+
+// U32 is a four byte little-endian integer (tag 0x7).
+type U32 uint32
+
+// U64 is an eight byte little-endian integer (tag 0x8).
+type U64 uint64
+
+// VarInt is a variable-length integer (tag 0x9).
+type VarInt int64
+
+func (a Array) appendTo(buf []byte) ([]byte, error) {
+	var err error
+	buf = append(buf, 0x0)
+	buf, _ = appendVarInt(buf, int64(len(a)))
+	for _, elem := range a {
+		if elem == nil {
+			return buf, fmt.Errorf("blizzval: nil array element")
+		}
+		if buf, err = elem.appendTo(buf); err != nil {
+			return buf, err
+		}
+	}
+	return buf, nil
+}
+
+func (b BitArray) appendTo(buf []byte) ([]byte, error) {
+	if b.Len < 0 || len(b.Bytes) != (b.Len+7)/8 {
+		return buf, fmt.Errorf("blizzval: %d bytes for a bit array of %d bits", len(b.Bytes), b.Len)
+	}
+	buf = append(buf, 0x1)
+	buf, _ = appendVarInt(buf, int64(b.Len))
+	return append(buf, b.Bytes...), nil
+}
+
+func (b Blob) appendTo(buf []byte) ([]byte, error) {
+	buf = append(buf, 0x2)
+	buf, _ = appendVarInt(buf, int64(len(b)))
+	return append(buf, b...), nil
+}
+
+func (c Choice) appendTo(buf []byte) ([]byte, error) {
+	var err error
+	if c.Value == nil {
+		return buf, fmt.Errorf("blizzval: nil value for choice %d", c.Tag)
+	}
+	buf = append(buf, 0x3)
+	if buf, err = appendVarInt(buf, int64(c.Tag)); err != nil {
+		return buf, err
+	}
+	return c.Value.appendTo(buf)
+}
+
+func (o Optional) appendTo(buf []byte) ([]byte, error) {
+	if o.Value == nil {
+		return append(buf, 0x4, 0), nil
+	}
+	return o.Value.appendTo(append(buf, 0x4, 1))
+}
+
+func (s Struct) appendTo(buf []byte) ([]byte, error) {
+	var err error
+	buf = append(buf, 0x5)
+	buf, _ = appendVarInt(buf, int64(len(s.Fields)))
+	for _, f := range s.Fields {
+		if f.Value == nil {
+			return buf, fmt.Errorf("blizzval: nil value for key %d", f.Key)
+		}
+		if buf, err = appendVarInt(buf, int64(f.Key)); err != nil {
+			return buf, err
+		}
+		if buf, err = f.Value.appendTo(buf); err != nil {
+			return buf, err
+		}
+	}
+	return buf, nil
+}
+
+func (u U8) appendTo(buf []byte) ([]byte, error) {
+	return append(buf, 0x6, byte(u)), nil
+}
+
+func (u U32) appendTo(buf []byte) ([]byte, error) {
+	return binary.LittleEndian.AppendUint32(append(buf, 0x7), uint32(u)), nil
+}
+
+func (u U64) appendTo(buf []byte) ([]byte, error) {
+	return binary.LittleEndian.AppendUint64(append(buf, 0x8), uint64(u)), nil
+}
+
+func (v VarInt) appendTo(buf []byte) ([]byte, error) {
+	return appendVarInt(append(buf, 0x9), int64(v))
+}
+
+// Get returns the value for key, if present.
+func (s Struct) Get(key int) (TypedValue, bool) {
+	for _, f := range s.Fields {
+		if f.Key == key {
+			return f.Value, true
+		}
+	}
+	return nil, false
+}
+
+// lookup returns the value for key, looking through present optionals.
+func (s Struct) lookup(key int, want string) (TypedValue, error) {
+	v, ok := s.Get(key)
+	if !ok {
+		return nil, fmt.Errorf("blizzval: missing key %d", key)
+	}
+	if o, ok := v.(Optional); ok {
+		if o.Value == nil {
+			return nil, fmt.Errorf("blizzval: key %d is an absent optional, not %s", key, want)
+		}
+		v = o.Value
+	}
+	return v, nil
+}
+
+func typedMismatch(key int, v TypedValue, want string) error {
+	return fmt.Errorf("blizzval: key %d is %s, not %s", key, typeName(Untyped(v)), want)
+}
+
+// Int returns the integer value for key, which may be a U8, U32, U64
+// or VarInt.  A U64 too large for an int64 is an error.
+func (s Struct) Int(key int) (int64, error) {
+	v, err := s.lookup(key, "an integer")
+	if err != nil {
+		return 0, err
+	}
+	switch v := v.(type) {
+	case U8:
+		return int64(v), nil
+	case U32:
+		return int64(v), nil
+	case U64:
+		if v > math.MaxInt64 {
+			return 0, fmt.Errorf("blizzval: key %d is u64 %d, which overflows int64", key, uint64(v))
+		}
+		return int64(v), nil
+	case VarInt:
+		return int64(v), nil
+	}
+	return 0, typedMismatch(key, v, "an integer")
+}
+
+// Blob returns the blob value for key.
+func (s Struct) Blob(key int) ([]byte, error) {
+	v, err := s.lookup(key, "a blob")
+	if err != nil {
+		return nil, err
+	}
+	b, ok := v.(Blob)
+	if !ok {
+		return nil, typedMismatch(key, v, "a blob")
+	}
+	return b, nil
+}
+
+// String returns the blob value for key as a string.
+func (s Struct) String(key int) (string, error) {
+	b, err := s.Blob(key)
+	return string(b), err
+}
+
+// Array returns the array value for key.
+func (s Struct) Array(key int) (Array, error) {
+	v, err := s.lookup(key, "an array")
+	if err != nil {
+		return nil, err
+	}
+	a, ok := v.(Array)
+	if !ok {
+		return nil, typedMismatch(key, v, "an array")
+	}
+	return a, nil
+}
+
+// Struct returns the struct value for key.
+func (s Struct) Struct(key int) (Struct, error) {
+	v, err := s.lookup(key, "a struct")
+	if err != nil {
+		return Struct{}, err
+	}
+	st, ok := v.(Struct)
+	if !ok {
+		return Struct{}, typedMismatch(key, v, "a struct")
+	}
+	return st, nil
+}
+
+// DecodeTyped reads an encoded value from an io.Reader, preserving
+// wire types and field order.  Errors are reported as for Decode.
+func DecodeTyped(r io.Reader) (TypedValue, error) {
+	return newReader(r).decodeTyped(0)
+}
+
+func (r *reader) decodeTyped(depth int) (TypedValue, error) {
+	ofs := r.ofs
+	tag, err := r.read8()
+	if err != nil {
+		return nil, err
+	}
+	val, err := r.decodeTypedTagged(tag, depth)
+	if err != nil {
+		if _, ok := err.(*DecodeError); !ok {
+			err = &DecodeError{Offset: ofs, Tag: tag, Err: unexpectedEOF(err)}
+		}
+		return nil, err
+	}
+	return val, nil
+}
+
+// decodeTypedElem decodes a value nested within an array, struct or
+// optional.
+func (r *reader) decodeTypedElem(depth int) (TypedValue, error) {
+	if depth >= MaxDepth {
+		return nil, errTooDeep
+	}
+	val, err := r.decodeTyped(depth + 1)
+	return val, unexpectedEOF(err)
+}
+
+func (r *reader) decodeTypedTagged(tag byte, depth int) (TypedValue, error) {
+	switch tag {
+	case 0x0:
+		size, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
+		// Grow the array as elements arrive rather than trusting size.
+		var array Array
+		for i := 0; i < size; i++ {
+			val, err := r.decodeTypedElem(depth)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, val)
+		}
+		return array, nil
+	case 0x1:
+		size, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, (size+7)/8)
+		_, err = r.readFull(buf)
+		return BitArray{size, buf}, err
+	case 0x2:
+		size, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
+		buf := make(Blob, size)
+		_, err = r.readFull(buf)
+		return buf, err
+	case 0x3:
+		tag, err := r.readVarInt()
+		if err != nil {
+			return nil, err
+		}
+		val, err := r.decodeTypedElem(depth)
+		return Choice{int(tag), val}, err
+	case 0x4:
+		present, err := r.read8()
+		if err != nil || present == 0 {
+			return Optional{}, err
+		}
+		val, err := r.decodeTypedElem(depth)
+		return Optional{val}, err
+	case 0x5:
+		size, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
+		var s Struct
+		for i := 0; i < size; i++ {
+			key, err := r.readVarInt()
+			if err != nil {
+				return nil, err
+			}
+			val, err := r.decodeTypedElem(depth)
+			if err != nil {
+				return nil, err
+			}
+			s.Fields = append(s.Fields, Field{int(key), val})
+		}
+		return s, nil
+	case 0x6:
+		b, err := r.read8()
+		return U8(b), err
+	case 0x7:
+		u, err := r.read32()
+		return U32(u), err
+	case 0x8:
+		u, err := r.read64()
+		return U64(u), err
+	case 0x9:
+		v, err := r.readVarInt()
+		return VarInt(v), err
+	default:
+		return nil, fmt.Errorf("unknown tag")
+	}
+}
+
+// Untyped converts a TypedValue to the plain Value representation.
+func Untyped(t TypedValue) Value {
+	switch t := t.(type) {
+	case Array:
+		arr := make([]Value, len(t))
+		for i, elem := range t {
+			arr[i] = Untyped(elem)
+		}
+		return arr
+	case Blob:
+		return string(t)
+	case Optional:
+		if t.Value == nil {
+			return nil
+		}
+		return Untyped(t.Value)
+	case Struct:
+		m := make(map[int]Value, len(t.Fields))
+		for _, f := range t.Fields {
+			m[f.Key] = Untyped(f.Value)
+		}
+		return m
+	case U8:
+		return uint8(t)
+	case U32:
+		return uint32(t)
+	case U64:
+		return uint64(t)
+	case VarInt:
+		return int64(t)
+	case BitArray:
+		return t
+	case Choice:
+		return Variant{t.Tag, Untyped(t.Value)}
+	}
+	return nil
+}
+
+// Typed converts a plain Value to a TypedValue.  nil becomes an absent
+// Optional and map entries become Struct fields in ascending key order.
+func Typed(v Value) (TypedValue, error) {
+	switch v := v.(type) {
+	case []Value:
+		arr := make(Array, len(v))
+		for i, elem := range v {
+			t, err := Typed(elem)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = t
+		}
+		return arr, nil
+	case string:
+		return Blob(v), nil
+	case nil:
+		return Optional{}, nil
+	case map[int]Value:
+		s := Struct{Fields: make([]Field, 0, len(v))}
+		for k, elem := range v {
+			t, err := Typed(elem)
+			if err != nil {
+				return nil, err
+			}
+			s.Fields = append(s.Fields, Field{k, t})
+		}
+		sort.Slice(s.Fields, func(i, j int) bool { return s.Fields[i].Key < s.Fields[j].Key })
+		return s, nil
+	case uint8:
+		return U8(v), nil
+	case uint32:
+		return U32(v), nil
+	case uint64:
+		return U64(v), nil
+	case int64:
+		return VarInt(v), nil
+	case BitArray:
+		return v, nil
+	case Variant:
+		t, err := Typed(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		return Choice{v.Tag, t}, nil
+	}
+	return nil, fmt.Errorf("blizzval: cannot convert %T", v)
+}
@@ -0,0 +1,130 @@
+package blizzval
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTypedRoundTrip(t *testing.T) {
+	// Keys out of order, a present optional, and a u8 and a varint
+	// with equal values.
+	in := "\x05\x06" + "\x0a\x04\x01\x02\x04ab" + "\x00\x06\x07" + "\x02\x09\x0e"
+	v, err := DecodeTyped(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := Struct{[]Field{
+		{5, Optional{Blob("ab")}},
+		{0, U8(7)},
+		{1, VarInt(7)},
+	}}
+	if !reflect.DeepEqual(v, exp) {
+		t.Fatalf("got %#v, expected %#v", v, exp)
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, v); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != in {
+		t.Errorf("re-encoded as %q, expected %q", buf.String(), in)
+	}
+
+	untyped := map[int]Value{5: "ab", 0: uint8(7), 1: int64(7)}
+	if !reflect.DeepEqual(Untyped(v), untyped) {
+		t.Errorf("untyped %#v, expected %#v", Untyped(v), untyped)
+	}
+}
+
+func TestTypedTags(t *testing.T) {
+	// A bit array of 10 bits, a choice of variant 1 holding a varint,
+	// and a u64.
+	in := "\x00\x06" + "\x01\x14\x02\x01" + "\x03\x02\x09\x04" + "\x08\x01\x00\x00\x00\x00\x00\x00\x80"
+	v, err := DecodeTyped(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := Array{BitArray{10, []byte{0x02, 0x01}}, Choice{1, VarInt(2)}, U64(1<<63 + 1)}
+	if !reflect.DeepEqual(v, exp) {
+		t.Fatalf("got %#v, expected %#v", v, exp)
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, v); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != in {
+		t.Errorf("re-encoded as %q, expected %q", buf.String(), in)
+	}
+
+	untyped := []Value{BitArray{10, []byte{0x02, 0x01}}, Variant{1, int64(2)}, uint64(1<<63 + 1)}
+	if val, err := Decode(strings.NewReader(in)); err != nil || !reflect.DeepEqual(val, untyped) {
+		t.Errorf("decoded %#v, %v, expected %#v", val, err, untyped)
+	}
+	if val := Untyped(v); !reflect.DeepEqual(val, untyped) {
+		t.Errorf("untyped %#v, expected %#v", val, untyped)
+	}
+	if typed, err := Typed(untyped); err != nil || !reflect.DeepEqual(typed, exp) {
+		t.Errorf("typed %#v, %v, expected %#v", typed, err, exp)
+	}
+
+	d := NewDecoder(strings.NewReader(in[len(in)-9:]))
+	if tok, err := d.Token(); err != nil || tok.Kind != KindInt || tok.Tag != 0x8 || uint64(tok.Int) != 1<<63+1 {
+		t.Errorf("got token %+v, %v, expected u64", tok, err)
+	}
+}
+
+func TestStructAccessors(t *testing.T) {
+	s := Struct{[]Field{
+		{0, Blob("name")},
+		{1, U32(3)},
+		{2, Optional{VarInt(-4)}},
+		{3, Optional{}},
+		{4, Struct{}},
+	}}
+	if name, err := s.String(0); err != nil || name != "name" {
+		t.Errorf("String(0) = %q, %v", name, err)
+	}
+	if n, err := s.Int(1); err != nil || n != 3 {
+		t.Errorf("Int(1) = %d, %v", n, err)
+	}
+	if n, err := s.Int(2); err != nil || n != -4 {
+		t.Errorf("Int(2) = %d, %v", n, err)
+	}
+	if _, err := s.Struct(4); err != nil {
+		t.Errorf("Struct(4): %v", err)
+	}
+
+	for _, test := range []struct {
+		err error
+		exp string
+	}{
+		{second(s.Int(0)), "key 0 is blob, not an integer"},
+		{second(s.String(1)), "key 1 is u32, not a blob"},
+		{second(s.Int(3)), "key 3 is an absent optional"},
+		{second(s.Int(9)), "missing key 9"},
+		{second(s.Array(4)), "key 4 is map, not an array"},
+	} {
+		if test.err == nil || !strings.Contains(test.err.Error(), test.exp) {
+			t.Errorf("got error %v, expected %q", test.err, test.exp)
+		}
+	}
+}
+
+func second(_ interface{}, err error) error {
+	return err
+}
+
+func TestTyped(t *testing.T) {
+	v := map[int]Value{3: nil, 1: []Value{"x", uint32(1)}}
+	typed, err := Typed(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := Struct{[]Field{{1, Array{Blob("x"), U32(1)}}, {3, Optional{}}}}
+	if !reflect.DeepEqual(typed, exp) {
+		t.Errorf("got %#v, expected %#v", typed, exp)
+	}
+}
@@ -0,0 +1,238 @@
+package blizzval
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// UnmarshalError describes a value that could not be stored in a field.
+type UnmarshalError struct {
+	Path string // path to the field, such as "Details.Players[2].Name"
+	Msg  string
+}
+
+func (e *UnmarshalError) Error() string {
+	return fmt.Sprintf("blizzval: %s: %s", e.Path, e.Msg)
+}
+
+// typeName describes the type of a Value for error messages.
+func typeName(v Value) string {
+	switch v.(type) {
+	case []Value:
+		return "array"
+	case string:
+		return "blob"
+	case nil:
+		return "nil"
+	case map[int]Value:
+		return "map"
+	case uint8:
+		return "u8"
+	case uint32:
+		return "u32"
+	case uint64:
+		return "u64"
+	case int64:
+		return "varint"
+	case BitArray:
+		return "bit array"
+	case Variant:
+		return "choice"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// Unmarshal stores v in the value pointed to by out.
+//
+// Structs are decoded from maps.  Each struct field with a tag like
+// `blizz:"5"` takes the map entry with key 5; untagged fields and
+// fields tagged "-" are left alone.  A missing key is an error unless
+// the tag has the omitempty option, as in `blizz:"5,omitempty"`.  Any
+// other tag is an error.
+//
+// Pointers decode optional values, and are set to nil for an absent
+// optional.  Slices decode arrays, except that []byte and string decode
+// blobs.  Integer and bool fields accept any integer value that fits.
+// Fields of type Value (or any empty interface) receive the raw value.
+func Unmarshal(v Value, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("blizzval: Unmarshal requires a non-nil pointer")
+	}
+	return unmarshal(v, rv.Elem(), rv.Elem().Type().Name())
+}
+
+func toInt64(v Value) (int64, bool) {
+	switch v := v.(type) {
+	case uint8:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return int64(v), v <= math.MaxInt64
+	case int64:
+		return v, true
+	}
+	return 0, false
+}
+
+func unmarshal(v Value, out reflect.Value, path string) error {
+	mismatch := func() error {
+		return &UnmarshalError{path, fmt.Sprintf("cannot store %s in %s", typeName(v), out.Type())}
+	}
+
+	switch out.Kind() {
+	case reflect.Interface:
+		if out.NumMethod() != 0 {
+			break
+		}
+		if v == nil {
+			out.Set(reflect.Zero(out.Type()))
+		} else {
+			out.Set(reflect.ValueOf(v))
+		}
+		return nil
+
+	case reflect.Ptr:
+		if v == nil {
+			out.Set(reflect.Zero(out.Type()))
+			return nil
+		}
+		p := reflect.New(out.Type().Elem())
+		if err := unmarshal(v, p.Elem(), path); err != nil {
+			return err
+		}
+		out.Set(p)
+		return nil
+
+	case reflect.Struct:
+		m, ok := v.(map[int]Value)
+		if !ok {
+			return mismatch()
+		}
+		t := out.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			fieldPath := path + "." + field.Name
+			key, omitempty, ok, err := parseTag(field.Tag.Get("blizz"))
+			if err != nil {
+				return &UnmarshalError{fieldPath, err.Error()}
+			}
+			if !ok {
+				continue
+			}
+			fv, present := m[key]
+			if !present {
+				if omitempty {
+					continue
+				}
+				return &UnmarshalError{fieldPath, fmt.Sprintf("missing key %d", key)}
+			}
+			if err := unmarshal(fv, out.Field(i), fieldPath); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Slice:
+		if out.Type().Elem().Kind() == reflect.Uint8 {
+			s, ok := v.(string)
+			if !ok {
+				return mismatch()
+			}
+			out.SetBytes([]byte(s))
+			return nil
+		}
+		arr, ok := v.([]Value)
+		if !ok {
+			return mismatch()
+		}
+		s := reflect.MakeSlice(out.Type(), len(arr), len(arr))
+		for i, elem := range arr {
+			if err := unmarshal(elem, s.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		out.Set(s)
+		return nil
+
+	case reflect.String:
+		s, ok := v.(string)
+		if !ok {
+			return mismatch()
+		}
+		out.SetString(s)
+		return nil
+
+	case reflect.Bool:
+		if u, ok := v.(uint64); ok {
+			out.SetBool(u != 0)
+			return nil
+		}
+		n, ok := toInt64(v)
+		if !ok {
+			return mismatch()
+		}
+		out.SetBool(n != 0)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if u, ok := v.(uint64); ok && u > math.MaxInt64 {
+			return &UnmarshalError{path, fmt.Sprintf("%d overflows %s", u, out.Type())}
+		}
+		n, ok := toInt64(v)
+		if !ok {
+			return mismatch()
+		}
+		if out.OverflowInt(n) {
+			return &UnmarshalError{path, fmt.Sprintf("%d overflows %s", n, out.Type())}
+		}
+		out.SetInt(n)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u, ok := v.(uint64); ok {
+			if out.OverflowUint(u) {
+				return &UnmarshalError{path, fmt.Sprintf("%d overflows %s", u, out.Type())}
+			}
+			out.SetUint(u)
+			return nil
+		}
+		n, ok := toInt64(v)
+		if !ok {
+			return mismatch()
+		}
+		if n < 0 || out.OverflowUint(uint64(n)) {
+			return &UnmarshalError{path, fmt.Sprintf("%d overflows %s", n, out.Type())}
+		}
+		out.SetUint(uint64(n))
+		return nil
+	}
+	return &UnmarshalError{path, fmt.Sprintf("unsupported type %s", out.Type())}
+}
+
+// parseTag parses a `blizz:"key,omitempty"` struct tag, returning
+// ok=false if the field should be ignored.
+func parseTag(tag string) (key int, omitempty, ok bool, err error) {
+	if tag == "" || tag == "-" {
+		return 0, false, false, nil
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	key, err = strconv.Atoi(name)
+	if err != nil {
+		return 0, false, false, fmt.Errorf("bad key %q in tag %q", name, tag)
+	}
+	if opts != "" {
+		for _, opt := range strings.Split(opts, ",") {
+			if opt != "omitempty" {
+				return 0, false, false, fmt.Errorf("unknown option %q in tag %q", opt, tag)
+			}
+		}
+		omitempty = true
+	}
+	return key, omitempty, true, nil
+}
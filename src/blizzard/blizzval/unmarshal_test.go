@@ -0,0 +1,91 @@
+package blizzval
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testPlayer struct {
+	Raw     Value
+	Name    string  `blizz:"0"`
+	Team    int8    `blizz:"5"`
+	Color   *uint32 `blizz:"3,omitempty"`
+	Winner  bool    `blizz:"8"`
+	Ignored int
+}
+
+type testDetails struct {
+	Players []*testPlayer `blizz:"0"`
+	Map     []byte        `blizz:"1"`
+	Time    int64         `blizz:"5"`
+	Extra   *testPlayer   `blizz:"9,omitempty"`
+}
+
+func TestUnmarshal(t *testing.T) {
+	player := map[int]Value{0: "bob", 3: nil, 5: int64(1), 8: uint8(1), 99: "unknown"}
+	color := uint32(7)
+	in := map[int]Value{
+		0: []Value{player, map[int]Value{0: "al", 3: color, 5: uint8(2), 8: int64(0)}},
+		1: "map",
+		5: uint32(1234),
+	}
+
+	var d testDetails
+	if err := Unmarshal(in, &d); err != nil {
+		t.Fatal(err)
+	}
+	exp := testDetails{
+		Players: []*testPlayer{
+			{Raw: player, Name: "bob", Team: 1, Winner: true},
+			{Raw: nil, Name: "al", Team: 2, Color: &color},
+		},
+		Map:  []byte("map"),
+		Time: 1234,
+	}
+	// Raw is untagged, so it is left alone.
+	exp.Players[0].Raw = nil
+	if !reflect.DeepEqual(d, exp) {
+		t.Errorf("got %+v, expected %+v", d, exp)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for _, test := range []struct {
+		in  Value
+		err string
+	}{
+		{"x", "testDetails: cannot store blob in blizzval.testDetails"},
+		{map[int]Value{0: []Value{}, 1: "m"}, "testDetails.Time: missing key 5"},
+		{map[int]Value{0: []Value{map[int]Value{0: "a", 5: int64(300), 8: uint8(0)}}, 1: "m", 5: int64(0)},
+			"testDetails.Players[0].Team: 300 overflows int8"},
+		{map[int]Value{0: []Value{}, 1: int64(3), 5: int64(0)}, "testDetails.Map: cannot store varint in []uint8"},
+	} {
+		var d testDetails
+		err := Unmarshal(test.in, &d)
+		if err == nil || !strings.HasSuffix(err.Error(), test.err) {
+			t.Errorf("got error %v, expected %q", err, test.err)
+		}
+	}
+}
+
+func TestUnmarshalBadTags(t *testing.T) {
+	var badKey struct {
+		A int `blizz:"x"`
+	}
+	var badOption struct {
+		A int `blizz:"1,omitemtpy"`
+	}
+	for _, test := range []struct {
+		out interface{}
+		err string
+	}{
+		{&badKey, `.A: bad key "x" in tag "x"`},
+		{&badOption, `.A: unknown option "omitemtpy" in tag "1,omitemtpy"`},
+	} {
+		err := Unmarshal(map[int]Value{1: int64(1)}, test.out)
+		if _, ok := err.(*UnmarshalError); !ok || !strings.HasSuffix(err.Error(), test.err) {
+			t.Errorf("got error %v, expected UnmarshalError %q", err, test.err)
+		}
+	}
+}
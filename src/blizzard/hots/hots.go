@@ -2,17 +2,26 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 
-	"blizzard/mpq"
 	"blizzard/replay"
 )
 
-func walkGameEvents(mpqr *mpq.Reader) {
-	r := replay.NewGameEventReader(mpqr)
+func walkGameEvents(rep *replay.Replay) {
+	r, err := rep.GameEvents()
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
 	counts := map[string]int{}
-	for event := r.Read(); event != nil; event = r.Read() {
+	for {
+		event, err := r.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			log.Fatalf("%s", err)
+		}
 		switch event := event.(type) {
 		case *replay.BankFileEvent, *replay.BankSectionEvent, *replay.BankKeyEvent, *replay.BankSignatureEvent:
 			// Per-user counter metadata.
@@ -75,13 +84,11 @@ func main() {
 	}
 	path := os.Args[1]
 
-	f, err := os.Open(path)
+	rep, err := replay.Open(path)
 	if err != nil {
 		log.Fatalf("%s", err)
 	}
-	mpqr := mpq.NewReader(f)
+	defer rep.Close()
 
-	// readReplayDetails(&r)
-	// readReplayTrackerEvents(&r)
-	walkGameEvents(mpqr)
+	walkGameEvents(rep)
 }
@@ -0,0 +1,19 @@
+package mpq
+
+import "io"
+
+// NewDecrypter returns a reader decrypting r with key, as the hash and
+// block tables are read.
+func NewDecrypter(r io.Reader, key uint32) io.Reader {
+	return newDecrypter(r, key)
+}
+
+// CachedSectors returns the indexes of the sectors f has cached, most
+// recently used first.
+func CachedSectors(f *File) []int {
+	var indexes []int
+	for _, c := range f.cache {
+		indexes = append(indexes, c.index)
+	}
+	return indexes
+}
@@ -0,0 +1,46 @@
+package mpq
+
+import (
+	"bytes"
+	"os"
+	"syscall"
+)
+
+// mapping is a read-only memory mapping of an archive.
+type mapping []byte
+
+func (m mapping) Close() error {
+	return syscall.Munmap(m)
+}
+
+// OpenMapped opens the archive at path by mapping it into memory.
+// Tables and uncompressed sectors are then decoded directly from the
+// mapping, avoiding a system call per read, which helps when scanning
+// many archives.
+func OpenMapped(path string) (*Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	size := fi.Size()
+	if size == 0 || int64(int(size)) != size {
+		return OpenReader(path)
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, &os.PathError{Op: "mmap", Path: path, Err: err}
+	}
+	r, err := newReader(&Reader{r: bytes.NewReader(data), size: size, data: data})
+	if err != nil {
+		syscall.Munmap(data)
+		return nil, err
+	}
+	r.closer = mapping(data)
+	return r, nil
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package mpq
+
+// OpenMapped opens the archive at path.  Memory mapping is only
+// implemented on Linux; elsewhere this is equivalent to OpenReader.
+func OpenMapped(path string) (*Reader, error) {
+	return OpenReader(path)
+}
@@ -3,41 +3,63 @@ package mpq
 
 import (
 	"bufio"
+	"bytes"
 	"compress/bzip2"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"os"
 	"strings"
+	"sync"
 )
 
-func check(err error) {
-	if err != nil {
-		panic(err)
-	}
+// ErrFileNotFound is returned by OpenFile when the archive has no file
+// with the requested name.
+var ErrFileNotFound = errors.New("mpq: file not found")
+
+// Sanity limits applied to archives, which may come from untrusted
+// sources.
+const (
+	// maxBlockSize bounds the header's sector size shift; sectors
+	// larger than 512 << maxBlockSize bytes are not plausible.
+	maxBlockSize = 20
+
+	// maxExpansion bounds the uncompressed size of any file relative
+	// to the size of the whole archive, so a small archive cannot
+	// claim gigabytes of contents.
+	maxExpansion = 1 << 10
+)
+
+// leReader reads little-endian values from an io.Reader, remembering
+// the first error encountered so callers can check it once.
+type leReader struct {
+	r   io.Reader
+	err error
 }
 
-func read8(r io.Reader) (val uint8) {
-	var buf [1]byte
-	_, err := r.Read(buf[:])
-	check(err)
-	return buf[0]
+func (r *leReader) read(buf []byte) {
+	if r.err == nil {
+		_, r.err = io.ReadFull(r.r, buf)
+	}
 }
 
-func read16(r io.Reader) (val uint16) {
-	check(binary.Read(r, binary.LittleEndian, &val))
-	return
+func (r *leReader) read16() uint16 {
+	var buf [2]byte
+	r.read(buf[:])
+	return binary.LittleEndian.Uint16(buf[:])
 }
 
-func read32(r io.Reader) (val uint32) {
-	check(binary.Read(r, binary.LittleEndian, &val))
-	return
+func (r *leReader) read32() uint32 {
+	var buf [4]byte
+	r.read(buf[:])
+	return binary.LittleEndian.Uint32(buf[:])
 }
 
-func read64(r io.Reader) (val uint64) {
-	check(binary.Read(r, binary.LittleEndian, &val))
-	return
+func (r *leReader) read64() uint64 {
+	var buf [8]byte
+	r.read(buf[:])
+	return binary.LittleEndian.Uint64(buf[:])
 }
 
 var cryptTable [0x500]uint32
@@ -87,76 +109,202 @@ func Hash(in string, hashType HashType) uint32 {
 	return seed1
 }
 
+// cipher holds the state of the MPQ block cipher.
+type cipher struct {
+	key  uint32
+	seed uint32
+}
+
+func newCipher(key uint32) cipher {
+	return cipher{key: key, seed: 0xeeeeeeee}
+}
+
+// decrypt decrypts the whole 4-byte blocks of buf in place, leaving
+// any trailing partial block untouched.
+func (c *cipher) decrypt(buf []byte) {
+	key, seed := c.key, c.seed
+	for len(buf) >= 4 {
+		seed += cryptTable[0x400+key&0xFF]
+		block := binary.LittleEndian.Uint32(buf) ^ (key + seed)
+		binary.LittleEndian.PutUint32(buf, block)
+		key = ((^key << 0x15) + 0x11111111) | (key >> 0xB)
+		seed = block + seed + (seed << 5) + 3
+		buf = buf[4:]
+	}
+	c.key, c.seed = key, seed
+}
+
+// decryptBytes decrypts buf in place using the given key.
+func decryptBytes(buf []byte, key uint32) {
+	c := newCipher(key)
+	c.decrypt(buf)
+}
+
 type decrypter struct {
 	r        io.Reader // underlying reader
-	key      uint32
-	seed     uint32
+	c        cipher
 	extraBuf [4]byte
-	extra    []byte
+	extra    []byte // decrypted bytes not yet returned
 }
 
 func newDecrypter(r io.Reader, key uint32) *decrypter {
 	return &decrypter{
-		r:    r,
-		key:  key,
-		seed: 0xeeeeeeee,
+		r: r,
+		c: newCipher(key),
 	}
 }
 
 func (d *decrypter) Read(buf []byte) (n int, err error) {
-	n = 0
-	for n < len(buf) {
-		if d.extra != nil {
-			copied := copy(buf, d.extra)
-			n += copied
-			d.extra = d.extra[copied:]
-			if len(d.extra) == 0 {
-				d.extra = nil
-			}
-			continue
-		}
+	if len(d.extra) > 0 {
+		n = copy(buf, d.extra)
+		d.extra = d.extra[n:]
+	}
 
-		var block uint32
-		err = binary.Read(d.r, binary.LittleEndian, &block)
+	// Decrypt as many whole blocks as possible directly into buf.
+	if whole := (len(buf) - n) &^ 3; whole > 0 {
+		var read int
+		read, err = io.ReadFull(d.r, buf[n:n+whole])
+		full := read &^ 3
+		d.c.decrypt(buf[n : n+full])
+		n += full
 		if err != nil {
+			// A short read ending on a block boundary is a clean
+			// end of data, as if the blocks had been read singly.
+			if err == io.ErrUnexpectedEOF && read == full {
+				err = io.EOF
+			}
 			return
 		}
+	}
 
-		d.seed += cryptTable[0x400+d.key&0xFF]
-		block = block ^ (d.key + d.seed)
-		d.key = ((^d.key << 0x15) + 0x11111111) | (d.key >> 0xB)
-		d.seed = block + d.seed + (d.seed << 5) + 3
-		if len(buf) >= 4 {
-			binary.LittleEndian.PutUint32(buf[n:], block)
-			n += 4
-		} else {
-			d.extra = d.extraBuf[:]
-			binary.LittleEndian.PutUint32(d.extra, block)
+	// Fewer than four bytes remain: decrypt one more block and keep
+	// what doesn't fit for the next call.
+	if n < len(buf) {
+		d.extra = d.extraBuf[:]
+		_, err = io.ReadFull(d.r, d.extra)
+		if err != nil {
+			d.extra = nil
+			return
 		}
+		d.c.decrypt(d.extra)
+		copied := copy(buf[n:], d.extra)
+		n += copied
+		d.extra = d.extra[copied:]
 	}
 	return
 }
 
 // Reader reads an MPQ file.
 type Reader struct {
-	*os.File
+	r          io.ReaderAt
+	size       int64
+	closer     io.Closer // closed by Close, if non-nil
+	data       []byte    // contents of a memory-mapped archive
 	userData   userData
 	header     header
 	hashTable  []hashEntry
 	blockTable []blockEntry
 }
 
+// readAt fills buf from the archive at ofs, failing if any part of
+// the range lies outside the archive.
+func (r *Reader) readAt(buf []byte, ofs int64) error {
+	if ofs < 0 || ofs > r.size || int64(len(buf)) > r.size-ofs {
+		return fmt.Errorf("mpq: read of %d bytes at %#x is outside archive of %d bytes", len(buf), ofs, r.size)
+	}
+	n, err := r.r.ReadAt(buf, ofs)
+	if n == len(buf) {
+		return nil
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// bytesAt returns n bytes of the archive at ofs.  For a memory-mapped
+// archive the result aliases the mapping rather than being a copy.
+func (r *Reader) bytesAt(ofs, n int64) ([]byte, error) {
+	if r.data == nil {
+		buf := make([]byte, n)
+		return buf, r.readAt(buf, ofs)
+	}
+	if ofs < 0 || ofs > r.size || n > r.size-ofs {
+		return nil, fmt.Errorf("mpq: read of %d bytes at %#x is outside archive of %d bytes", n, ofs, r.size)
+	}
+	return r.data[ofs : ofs+n : ofs+n], nil
+}
+
+// table returns the decrypted contents of a table of n bytes at ofs.
+func (r *Reader) table(ofs, n int64, key uint32) ([]byte, error) {
+	buf, err := r.bytesAt(ofs, n)
+	if err != nil {
+		return nil, err
+	}
+	if r.data != nil {
+		// Don't decrypt the read-only mapping in place.
+		buf = append([]byte(nil), buf...)
+	}
+	decryptBytes(buf, key)
+	return buf, nil
+}
+
+// section returns a reader for the archive starting at ofs.
+func (r *Reader) section(ofs int64) *leReader {
+	if ofs < 0 || ofs > r.size {
+		ofs = r.size
+	}
+	return &leReader{r: io.NewSectionReader(r.r, ofs, r.size-ofs)}
+}
+
 type userData struct {
-	size      uint32
-	headerOfs uint32
-	unk       uint32
+	size      uint32 // space reserved for the user data block
+	headerOfs uint32 // offset of the archive header within the file
+	dataSize  uint32 // size of data
+	data      []byte
 }
 
-func (r *Reader) readUserData() {
+func (r *Reader) readUserData(ofs int64) error {
 	u := &r.userData
-	u.size = read32(r)
-	u.headerOfs = read32(r)
-	u.unk = read32(r)
+	lr := r.section(ofs)
+	u.size = lr.read32()
+	u.headerOfs = lr.read32()
+	u.dataSize = lr.read32()
+	if lr.err != nil {
+		return lr.err
+	}
+	if u.dataSize > u.size {
+		return fmt.Errorf("mpq: user data of %d bytes exceeds its %d byte block", u.dataSize, u.size)
+	}
+	// Check the size before allocating, lest a corrupt block ask for
+	// gigabytes.
+	if int64(u.size) > r.size-ofs-12 {
+		return fmt.Errorf("mpq: user data block of %d bytes is outside archive of %d bytes", u.size, r.size)
+	}
+	if int64(u.headerOfs) >= r.size {
+		return fmt.Errorf("mpq: header offset %#x is outside archive of %d bytes", u.headerOfs, r.size)
+	}
+	u.data = make([]byte, u.dataSize)
+	return r.readAt(u.data, ofs+12)
+}
+
+// UserData returns the contents of the user data block that precedes
+// the archive, or nil if the file has none.  Starcraft and Heroes of
+// the Storm replays store the replay header here.
+func (r *Reader) UserData() []byte {
+	return r.userData.data
+}
+
+// UserDataMaxSize returns the space reserved for the user data block,
+// which may be larger than len(UserData()).
+func (r *Reader) UserDataMaxSize() uint32 {
+	return r.userData.size
+}
+
+// HeaderOffset returns the offset of the archive header within the
+// file; it is nonzero only when a user data block is present.
+func (r *Reader) HeaderOffset() uint32 {
+	return r.userData.headerOfs
 }
 
 type header struct {
@@ -175,33 +323,51 @@ type header struct {
 	betTablePos, hetTablePos uint64
 }
 
-func (r *Reader) readHeader() {
+func (r *Reader) readHeader(ofs int64) error {
 	h := &r.header
-	h.headerSize = read32(r)
-	h.archiveSize = read32(r)
-	h.version = read16(r)
-	h.blockSize = read16(r)
-
-	h.hashTableOfs = read32(r)
-	h.blockTableOfs = read32(r)
-	h.hashTableEntries = read32(r)
-	h.blockTableEntries = read32(r)
+	lr := r.section(ofs)
+	h.headerSize = lr.read32()
+	h.archiveSize = lr.read32()
+	h.version = lr.read16()
+	h.blockSize = lr.read16()
+
+	h.hashTableOfs = lr.read32()
+	h.blockTableOfs = lr.read32()
+	h.hashTableEntries = lr.read32()
+	h.blockTableEntries = lr.read32()
+
+	if h.version >= 1 {
+		h.extendedBlockTableOfs = lr.read64()
+		h.hiHashTableOfs = lr.read16()
+		h.hiBlockTableOfs = lr.read16()
+	}
 
 	if h.version >= 2 {
-		h.extendedBlockTableOfs = read64(r)
-		h.hiHashTableOfs = read16(r)
-		h.hiBlockTableOfs = read16(r)
+		h.archiveSize64 = lr.read64()
+		h.betTablePos = lr.read64()
+		h.hetTablePos = lr.read64()
+	}
+	if lr.err != nil {
+		return lr.err
 	}
 
-	if h.version >= 3 {
-		h.archiveSize64 = read64(r)
-		h.betTablePos = read64(r)
-		h.hetTablePos = read64(r)
+	if h.version > 3 {
+		return fmt.Errorf("mpq: unknown format version %d", h.version)
+	}
+	if h.blockSize > maxBlockSize {
+		return fmt.Errorf("mpq: sector size shift %d too large", h.blockSize)
 	}
+	return nil
+}
 
-	if h.version >= 4 {
-		panic("unimplemented version")
+// tableOffset returns the absolute offset of a table of the given
+// number of 16-byte entries, checking that it lies within the archive.
+func (r *Reader) tableOffset(name string, lo uint32, hi uint16, entries uint32) (int64, error) {
+	ofs := int64(r.userData.headerOfs) + (int64(hi)<<32 | int64(lo))
+	if ofs > r.size || int64(entries)*16 > r.size-ofs {
+		return 0, fmt.Errorf("mpq: %s of %d entries at %#x is outside archive of %d bytes", name, entries, ofs, r.size)
 	}
+	return ofs, nil
 }
 
 type hashEntry struct {
@@ -212,21 +378,37 @@ type hashEntry struct {
 	blockIndex uint32
 }
 
-func (r *Reader) readHashTable() {
-	ofs := r.userData.headerOfs + r.header.hashTableOfs
-	_, err := r.Seek(int64(ofs), 0)
-	check(err)
+const (
+	hashEntryEmpty   = 0xffffffff
+	hashEntryDeleted = 0xfffffffe
+)
+
+func (r *Reader) readHashTable() error {
+	entries := r.header.hashTableEntries
+	if entries&(entries-1) != 0 {
+		return fmt.Errorf("mpq: hash table size %d is not a power of two", entries)
+	}
+	ofs, err := r.tableOffset("hash table", r.header.hashTableOfs, r.header.hiHashTableOfs, entries)
+	if err != nil {
+		return err
+	}
 
-	r.hashTable = make([]hashEntry, r.header.hashTableEntries)
-	d := newDecrypter(r, Hash("(hash table)", HashFileKey))
-	for i := uint32(0); i < r.header.hashTableEntries; i++ {
-		he := &r.hashTable[i]
-		he.pathHashA = read32(d)
-		he.pathHashB = read32(d)
-		he.language = read16(d)
-		he.platform = read16(d)
-		he.blockIndex = read32(d)
+	buf, err := r.table(ofs, int64(entries)*16, Hash("(hash table)", HashFileKey))
+	if err != nil {
+		return err
 	}
+	r.hashTable = make([]hashEntry, entries)
+	for i := range r.hashTable {
+		b := buf[i*16 : i*16+16]
+		r.hashTable[i] = hashEntry{
+			pathHashA:  binary.LittleEndian.Uint32(b[0:]),
+			pathHashB:  binary.LittleEndian.Uint32(b[4:]),
+			language:   binary.LittleEndian.Uint16(b[8:]),
+			platform:   binary.LittleEndian.Uint16(b[10:]),
+			blockIndex: binary.LittleEndian.Uint32(b[12:]),
+		}
+	}
+	return nil
 }
 
 const (
@@ -234,6 +416,8 @@ const (
 	BlockFlagCheckSums      uint32 = 1 << 26
 	BlockFlagDeletionMarker uint32 = 1 << 25
 	BlockFlagSingleUnit     uint32 = 1 << 24
+	BlockFlagFixKey         uint32 = 1 << 17
+	BlockFlagEncrypted      uint32 = 1 << 16
 	BlockFlagCompressed     uint32 = 1 << 9
 	BlockFlagImploded       uint32 = 1 << 8
 )
@@ -248,6 +432,8 @@ var flagNames []flagName = []flagName{
 	{BlockFlagCheckSums, "checksums"},
 	{BlockFlagDeletionMarker, "deletion marker"},
 	{BlockFlagSingleUnit, "single unit"},
+	{BlockFlagFixKey, "fix key"},
+	{BlockFlagEncrypted, "encrypted"},
 	{BlockFlagCompressed, "compressed"},
 	{BlockFlagImploded, "imploded"},
 }
@@ -275,79 +461,46 @@ func (be *blockEntry) flagsString() string {
 	return strings.Join(flagList, ", ")
 }
 
-func (r *Reader) readBlockTable() {
-	ofs := r.userData.headerOfs + r.header.blockTableOfs
-	_, err := r.Seek(int64(ofs), 0)
-	check(err)
-
-	r.blockTable = make([]blockEntry, r.header.blockTableEntries)
-	d := newDecrypter(r, Hash("(block table)", HashFileKey))
-	for i := uint32(0); i < r.header.blockTableEntries; i++ {
-		he := &r.blockTable[i]
-		he.offset = read32(d)
-		he.size = read32(d)
-		he.fileSize = read32(d)
-		he.flags = read32(d)
+func (r *Reader) readBlockTable() error {
+	entries := r.header.blockTableEntries
+	ofs, err := r.tableOffset("block table", r.header.blockTableOfs, r.header.hiBlockTableOfs, entries)
+	if err != nil {
+		return err
 	}
-}
 
-type het struct {
-	version  uint32
-	dataSize uint32
-
-	tableSize      uint32
-	maxFileCount   uint32
-	hashTableSize  uint32
-	hashEntrySize  uint32
-	totalIndexSize uint32
-	indexSizeExtra uint32
-	indexSize      uint32
-	blockTableSize uint32
-
-	table []byte
-}
-
-func (r *Reader) readHET() *het {
-	ofs := uint64(r.userData.headerOfs) + r.header.hetTablePos
-	var buf [4]byte
-	_, err := r.Seek(int64(ofs), 0)
-	check(err)
-	_, err = r.Read(buf[:])
-	check(err)
-	if string(buf[:]) != "HET\x1a" {
-		panic(fmt.Errorf("bad HET signature %q", buf))
-	}
-
-	h := het{}
-	h.version = read32(r)
-	h.dataSize = read32(r)
-
-	d := newDecrypter(r, Hash("(hash table)", HashFileKey))
-	h.tableSize = read32(d)
-	h.maxFileCount = read32(d)
-	h.hashTableSize = read32(d)
-	h.hashEntrySize = read32(d)
-	h.totalIndexSize = read32(d)
-	h.indexSizeExtra = read32(d)
-	h.indexSize = read32(d)
-	h.blockTableSize = read32(d)
-	log.Printf("%#v", h)
-	h.table = make([]byte, h.hashTableSize)
-	_, err = d.Read(h.table)
-	check(err)
-	log.Printf("%#v", h)
-	return &h
+	buf, err := r.table(ofs, int64(entries)*16, Hash("(block table)", HashFileKey))
+	if err != nil {
+		return err
+	}
+	r.blockTable = make([]blockEntry, entries)
+	for i := range r.blockTable {
+		b := buf[i*16 : i*16+16]
+		r.blockTable[i] = blockEntry{
+			offset:   binary.LittleEndian.Uint32(b[0:]),
+			size:     binary.LittleEndian.Uint32(b[4:]),
+			fileSize: binary.LittleEndian.Uint32(b[8:]),
+			flags:    binary.LittleEndian.Uint32(b[12:]),
+		}
+	}
+	return nil
 }
 
 func (r *Reader) findFile(name string) *hashEntry {
-	index := Hash(name, HashTableOffset) & (r.header.hashTableEntries - 1)
+	n := uint32(len(r.hashTable))
+	if n == 0 {
+		return nil
+	}
+	index := Hash(name, HashTableOffset) & (n - 1)
 	nameA := Hash(name, HashNameA)
 	nameB := Hash(name, HashNameB)
-	for i := index; i < uint32(len(r.hashTable)); i++ {
-		he := &r.hashTable[i]
-		if he.blockIndex == 0xffffffff {
+	for i := uint32(0); i < n; i++ {
+		he := &r.hashTable[(index+i)&(n-1)]
+		if he.blockIndex == hashEntryEmpty {
 			break
 		}
+		if he.blockIndex == hashEntryDeleted {
+			continue
+		}
 		if he.pathHashA == nameA && he.pathHashB == nameB {
 			return he
 		}
@@ -355,34 +508,257 @@ func (r *Reader) findFile(name string) *hashEntry {
 	return nil
 }
 
-// ReadFile reads a file from within the MPQ file.
-func (r *Reader) OpenFile(name string) io.Reader {
+// file describes where the contents of a file lie within the archive.
+type file struct {
+	r          *Reader
+	name       string
+	be         blockEntry
+	ofs        int64    // absolute offset of the file's data
+	sectorSize int64    // uncompressed size of each sector
+	sectors    []uint32 // sector offsets relative to ofs, if compressed
+}
+
+func (r *Reader) openFile(name string) (*file, error) {
 	he := r.findFile(name)
 	if he == nil {
-		return nil
+		return nil, ErrFileNotFound
+	}
+	if int(he.blockIndex) >= len(r.blockTable) {
+		return nil, fmt.Errorf("mpq: %s: block index %d out of range", name, he.blockIndex)
 	}
 	be := r.blockTable[he.blockIndex]
-	// log.Printf("blockEntry %#v", be)
+	if be.flags&BlockFlagFile == 0 || be.flags&BlockFlagDeletionMarker != 0 {
+		return nil, ErrFileNotFound
+	}
+	if be.flags&BlockFlagEncrypted != 0 {
+		return nil, fmt.Errorf("mpq: %s: encrypted files not implemented", name)
+	}
+	if be.flags&BlockFlagImploded != 0 {
+		return nil, fmt.Errorf("mpq: %s: imploded files not implemented", name)
+	}
+
+	f := &file{
+		r:    r,
+		name: name,
+		be:   be,
+		ofs:  int64(r.userData.headerOfs) + int64(be.offset),
+	}
+	if f.ofs > r.size || int64(be.size) > r.size-f.ofs {
+		return nil, fmt.Errorf("mpq: %s: data of %d bytes at %#x is outside archive of %d bytes", name, be.size, f.ofs, r.size)
+	}
+	if int64(be.fileSize) > r.size*maxExpansion {
+		return nil, fmt.Errorf("mpq: %s: implausible size %d for archive of %d bytes", name, be.fileSize, r.size)
+	}
+	compressed := be.flags&BlockFlagCompressed != 0
+	if !compressed && be.fileSize > be.size {
+		return nil, fmt.Errorf("mpq: %s: uncompressed size %d exceeds stored size %d", name, be.fileSize, be.size)
+	}
+
+	if be.flags&BlockFlagSingleUnit != 0 {
+		f.sectorSize = int64(be.fileSize)
+		if compressed {
+			f.sectors = []uint32{0, be.size}
+		}
+		return f, nil
+	}
+
+	f.sectorSize = 512 << r.header.blockSize
+	if compressed {
+		count := f.numSectors() + 1
+		if int64(count)*4 > int64(be.size) {
+			return nil, fmt.Errorf("mpq: %s: sector table of %d entries exceeds stored size %d", name, count, be.size)
+		}
+		buf, err := r.bytesAt(f.ofs, int64(count)*4)
+		if err != nil {
+			return nil, err
+		}
+		f.sectors = make([]uint32, count)
+		for i := range f.sectors {
+			f.sectors[i] = binary.LittleEndian.Uint32(buf[i*4:])
+			if i > 0 && f.sectors[i] < f.sectors[i-1] {
+				return nil, fmt.Errorf("mpq: %s: sector table is not sorted", name)
+			}
+		}
+		if f.sectors[0] < uint32(count*4) || f.sectors[count-1] > be.size {
+			return nil, fmt.Errorf("mpq: %s: sector table points outside file data", name)
+		}
+	}
+	return f, nil
+}
+
+// numSectors returns the number of sectors the file is stored in.
+func (f *file) numSectors() int {
+	if f.be.fileSize == 0 {
+		return 0
+	}
+	return int((int64(f.be.fileSize) + f.sectorSize - 1) / f.sectorSize)
+}
+
+// sectorLen returns the uncompressed size of sector i.
+func (f *file) sectorLen(i int) int64 {
+	start := int64(i) * f.sectorSize
+	if left := int64(f.be.fileSize) - start; left < f.sectorSize {
+		return left
+	}
+	return f.sectorSize
+}
+
+// readSector returns the uncompressed contents of sector i.  Sectors
+// stored uncompressed in a memory-mapped archive are not copied.
+func (f *file) readSector(i int) ([]byte, error) {
+	n := f.sectorLen(i)
+	start, end := int64(i)*f.sectorSize, int64(i)*f.sectorSize+n
+	if f.sectors != nil {
+		start, end = int64(f.sectors[i]), int64(f.sectors[i+1])
+	}
+	buf, err := f.r.bytesAt(f.ofs+start, end-start)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(buf)) == n {
+		return buf, nil
+	}
+	if f.sectors == nil || int64(len(buf)) > n || len(buf) == 0 {
+		return nil, fmt.Errorf("mpq: %s: sector %d is %d bytes, expected %d", f.name, i, len(buf), n)
+	}
+	return f.decompress(buf, n)
+}
+
+// decompress decompresses a sector of compressed data to n bytes.
+func (f *file) decompress(buf []byte, n int64) ([]byte, error) {
+	var r io.Reader
+	switch comp := buf[0]; comp {
+	case 0x10:
+		r = bzip2.NewReader(bytes.NewReader(buf[1:]))
+	default:
+		return nil, fmt.Errorf("mpq: %s: unknown compression %#x", f.name, comp)
+	}
+	// Read at most one byte more than expected, so that a corrupt
+	// sector cannot decompress to an unbounded size.
+	out, err := io.ReadAll(io.LimitReader(r, n+1))
+	if err != nil {
+		return nil, fmt.Errorf("mpq: %s: %s", f.name, err)
+	}
+	if int64(len(out)) != n {
+		return nil, fmt.Errorf("mpq: %s: sector decompressed to %d bytes, expected %d", f.name, len(out), n)
+	}
+	return out, nil
+}
+
+// sectorCacheSize is the number of decoded sectors each File keeps.
+const sectorCacheSize = 4
+
+type cachedSector struct {
+	index int
+	data  []byte
+}
+
+// File is a file opened from within an archive.  It implements
+// io.Reader, io.Seeker and io.ReaderAt, and decodes only the sectors
+// covering the data requested, caching the most recently used ones.
+type File struct {
+	f   *file
+	pos int64
+
+	mu    sync.Mutex
+	cache []cachedSector // most recently used first
+}
+
+// Size returns the uncompressed size of the file.
+func (f *File) Size() int64 {
+	return int64(f.f.be.fileSize)
+}
+
+// sector returns the decoded contents of sector i, using the cache.
+func (f *File) sector(i int) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for j, c := range f.cache {
+		if c.index == i {
+			copy(f.cache[1:j+1], f.cache[:j])
+			f.cache[0] = c
+			return c.data, nil
+		}
+	}
 
-	_, err := r.Seek(int64(r.userData.headerOfs+be.offset), 0)
-	check(err)
+	data, err := f.f.readSector(i)
+	if err != nil {
+		return nil, err
+	}
+	if len(f.cache) < sectorCacheSize {
+		f.cache = append(f.cache, cachedSector{})
+	}
+	copy(f.cache[1:], f.cache[:len(f.cache)-1])
+	f.cache[0] = cachedSector{i, data}
+	return data, nil
+}
 
-	if be.flags&BlockFlagCompressed != 0 {
-		comp := read8(r)
-		if comp == 0x10 {
-			return bzip2.NewReader(&io.LimitedReader{R: r, N: int64(be.size) - 1})
+func (f *File) ReadAt(buf []byte, ofs int64) (int, error) {
+	if ofs < 0 {
+		return 0, fmt.Errorf("mpq: %s: negative offset %d", f.f.name, ofs)
+	}
+	n := 0
+	for n < len(buf) {
+		pos := ofs + int64(n)
+		if pos >= f.Size() {
+			return n, io.EOF
 		}
-		panic(fmt.Errorf("compressed file with unknown compression %x", comp))
+		i := int(pos / f.f.sectorSize)
+		sector, err := f.sector(i)
+		if err != nil {
+			return n, err
+		}
+		n += copy(buf[n:], sector[pos-int64(i)*f.f.sectorSize:])
+	}
+	return n, nil
+}
+
+func (f *File) Read(buf []byte) (int, error) {
+	if f.pos >= f.Size() {
+		return 0, io.EOF
+	}
+	n, err := f.ReadAt(buf, f.pos)
+	f.pos += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
 	}
-	panic("uncompressed not implemented")
+	return n, err
+}
+
+func (f *File) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekEnd:
+		offset += f.Size()
+	default:
+		return f.pos, fmt.Errorf("mpq: %s: bad whence %d", f.f.name, whence)
+	}
+	if offset < 0 {
+		return f.pos, fmt.Errorf("mpq: %s: negative offset %d", f.f.name, offset)
+	}
+	f.pos = offset
+	return f.pos, nil
+}
+
+// OpenFile opens a file from within the MPQ file for reading.
+func (r *Reader) OpenFile(name string) (*File, error) {
+	f, err := r.openFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return &File{f: f}, nil
 }
 
 // GetFileList returns a list of the files contained in the MPQ
-// according to its "(listfile") metafile, if present.
-func (r *Reader) GetFileList() []string {
-	fr := r.OpenFile("(listfile)")
-	if fr == nil {
-		return nil
+// according to its "(listfile") metafile, or nil if there is none.
+func (r *Reader) GetFileList() ([]string, error) {
+	fr, err := r.OpenFile("(listfile)")
+	if err == ErrFileNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
 	}
 
 	files := []string{}
@@ -391,40 +767,75 @@ func (r *Reader) GetFileList() []string {
 		files = append(files, s.Text())
 	}
 	if err := s.Err(); err != nil {
-		panic(err)
+		return nil, err
 	}
-	return files
+	return files, nil
 }
 
-func (r *Reader) readSection() {
+func (r *Reader) readHeaders() error {
 	var buf [4]byte
-	_, err := r.Read(buf[:])
-	check(err)
-	switch string(buf[:]) {
-	case "MPQ\x1b": // user data
-		r.readUserData()
-	case "MPQ\x1a": // file header
-		r.readHeader()
-		r.readHashTable()
-		r.readBlockTable()
-		//r.readHET()
-	default:
-		panic(fmt.Errorf("section id: %q", buf))
+	if err := r.readAt(buf[:], 0); err != nil {
+		return err
+	}
+	if string(buf[:]) == "MPQ\x1b" { // user data
+		if err := r.readUserData(4); err != nil {
+			return err
+		}
+		if err := r.readAt(buf[:], int64(r.userData.headerOfs)); err != nil {
+			return err
+		}
+	}
+	if string(buf[:]) != "MPQ\x1a" { // file header
+		return fmt.Errorf("mpq: bad section id %q", buf)
 	}
+	if err := r.readHeader(int64(r.userData.headerOfs) + 4); err != nil {
+		return err
+	}
+	if err := r.readHashTable(); err != nil {
+		return err
+	}
+	return r.readBlockTable()
 }
 
-func (r *Reader) readHeaders() {
-	r.readSection()
-	if r.userData.headerOfs != 0 {
-		_, err := r.Seek(int64(r.userData.headerOfs), 0)
-		check(err)
-		r.readSection()
+// NewReader reads the headers of an archive of the given size,
+// returning an opened Reader.
+func NewReader(ra io.ReaderAt, size int64) (*Reader, error) {
+	return newReader(&Reader{r: ra, size: size})
+}
+
+func newReader(r *Reader) (*Reader, error) {
+	if err := r.readHeaders(); err != nil {
+		return nil, err
 	}
+	return r, nil
 }
 
-// NewReader reads the header of a file, returning an opened Reader.
-func NewReader(f *os.File) *Reader {
-	r := &Reader{File: f}
-	r.readHeaders()
-	return r
+// OpenReader opens the archive at path.
+func OpenReader(path string) (*Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	r, err := NewReader(f, fi.Size())
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	r.closer = f
+	return r, nil
+}
+
+// Close closes the archive if it was opened by OpenReader or
+// OpenMapped.  Files opened from the archive must not be used after it
+// is closed.
+func (r *Reader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
 }
@@ -0,0 +1,374 @@
+package mpq_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"blizzard/mpq"
+	"blizzard/mpq/mpqtest"
+)
+
+// helloBzip2 is "hello, world\n" repeated 20 times, bzip2-compressed.
+var helloBzip2, _ = hex.DecodeString("425a6839314159265359c666fc5f00003bd18000104004064490802000508018052a8d0f44d1344c261309e9364d93c1384f89c27e2ee48a70a1218ccdf8be")
+
+var helloText = strings.Repeat("hello, world\n", 20)
+
+// sectoredFile stores data in sectors of the default 4096-byte size,
+// with a sector offset table but every sector left uncompressed.
+func sectoredFile(name string, data []byte) mpqtest.File {
+	const sectorSize = 4096
+	count := (len(data) + sectorSize - 1) / sectorSize
+	offsets := make([]uint32, count+1)
+	offsets[0] = uint32(4 * len(offsets))
+	for i := 1; i <= count; i++ {
+		offsets[i] = offsets[0] + uint32(len(data))
+		if i*sectorSize < len(data) {
+			offsets[i] = offsets[0] + uint32(i*sectorSize)
+		}
+	}
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, offsets)
+	buf.Write(data)
+	return mpqtest.File{Name: name, Data: buf.Bytes(), Size: len(data), Flags: mpq.BlockFlagFile | mpq.BlockFlagCompressed}
+}
+
+func testData(n int) []byte {
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(i * 7 / 3)
+	}
+	return data
+}
+
+const testListfile = "hello.txt\r\nraw.bin\r\nsectors.bin\r\n"
+
+var testFiles = []mpqtest.File{
+	{Name: "hello.txt", Data: append([]byte{0x10}, helloBzip2...), Size: len(helloText), Flags: mpq.BlockFlagFile | mpq.BlockFlagSingleUnit | mpq.BlockFlagCompressed},
+	{Name: "raw.bin", Data: testData(100)},
+	{Name: "(listfile)", Data: []byte(testListfile), Size: len(testListfile), Flags: mpq.BlockFlagFile | mpq.BlockFlagSingleUnit},
+	sectoredFile("sectors.bin", testData(10000)),
+}
+
+var testContents = map[string]string{
+	"hello.txt":   helloText,
+	"raw.bin":     string(testData(100)),
+	"sectors.bin": string(testData(10000)),
+}
+
+func openTestArchive(t testing.TB, userData []byte) *mpq.Reader {
+	data := mpqtest.Build(userData, testFiles...)
+	r, err := mpq.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("NewReader: %s", err)
+	}
+	return r
+}
+
+func TestOpenFile(t *testing.T) {
+	r := openTestArchive(t, nil)
+	for name, exp := range testContents {
+		f, err := r.OpenFile(name)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		got, err := io.ReadAll(f)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if string(got) != exp {
+			t.Errorf("%s: got %d bytes, expected %d", name, len(got), len(exp))
+		}
+	}
+	if _, err := r.OpenFile("missing"); err != mpq.ErrFileNotFound {
+		t.Errorf("missing file: got %v, expected mpq.ErrFileNotFound", err)
+	}
+
+	files, err := r.GetFileList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(files, ",") != "hello.txt,raw.bin,sectors.bin" {
+		t.Errorf("unexpected file list %q", files)
+	}
+}
+
+func TestFileSeek(t *testing.T) {
+	r := openTestArchive(t, nil)
+	f, err := r.OpenFile("sectors.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := testData(10000)
+	if f.Size() != int64(len(exp)) {
+		t.Fatalf("size %d, expected %d", f.Size(), len(exp))
+	}
+
+	// A read in the last sector should decode only that sector.
+	buf := make([]byte, 100)
+	if _, err := f.ReadAt(buf, 9000); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf, exp[9000:9100]) {
+		t.Errorf("ReadAt(9000) returned wrong data")
+	}
+	if cached := mpq.CachedSectors(f); len(cached) != 1 || cached[0] != 2 {
+		t.Errorf("unexpected sectors cached: %v", cached)
+	}
+
+	// A read spanning sectors 0 and 1.
+	if _, err := f.Seek(4000, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadFull(f, buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf, exp[4000:4100]) {
+		t.Errorf("read at 4000 returned wrong data")
+	}
+
+	if _, err := f.Seek(-50, io.SeekEnd); err != nil {
+		t.Fatal(err)
+	}
+	rest, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(rest, exp[len(exp)-50:]) {
+		t.Errorf("read at end returned wrong data")
+	}
+	if n, err := f.ReadAt(buf, int64(len(exp)-10)); n != 10 || err != io.EOF {
+		t.Errorf("ReadAt past end: got %d, %v", n, err)
+	}
+}
+
+func TestUserData(t *testing.T) {
+	r := openTestArchive(t, []byte("replay header"))
+	if string(r.UserData()) != "replay header" {
+		t.Errorf("unexpected user data %q", r.UserData())
+	}
+	if r.HeaderOffset() != 0x200 || r.UserDataMaxSize() != 0x200-16 {
+		t.Errorf("unexpected header offset %#x, user data size %#x", r.HeaderOffset(), r.UserDataMaxSize())
+	}
+	if _, err := r.OpenFile("raw.bin"); err != nil {
+		t.Error(err)
+	}
+
+	r = openTestArchive(t, nil)
+	if r.UserData() != nil || r.HeaderOffset() != 0 {
+		t.Errorf("unexpected user data %q at %#x", r.UserData(), r.HeaderOffset())
+	}
+}
+
+func writeTestArchive(t testing.TB, files []mpqtest.File) string {
+	path := filepath.Join(t.TempDir(), "test.mpq")
+	if err := os.WriteFile(path, mpqtest.Build(nil, files...), 0666); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestOpenMapped(t *testing.T) {
+	r, err := mpq.OpenMapped(writeTestArchive(t, testFiles))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	for name, exp := range testContents {
+		f, err := r.OpenFile(name)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		got, err := io.ReadAll(f)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if string(got) != exp {
+			t.Errorf("%s: got %d bytes, expected %d", name, len(got), len(exp))
+		}
+	}
+}
+
+func benchmarkReadFiles(b *testing.B, open func(string) (*mpq.Reader, error)) {
+	var files []mpqtest.File
+	for i := 0; i < 100; i++ {
+		files = append(files, sectoredFile(fmt.Sprintf("file%d", i), testData(256<<10)))
+	}
+	path := writeTestArchive(b, files)
+	buf := make([]byte, 4096)
+
+	b.SetBytes(int64(len(files)) * 256 << 10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r, err := open(path)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, tf := range files {
+			f, err := r.OpenFile(tf.Name)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := io.CopyBuffer(io.Discard, struct{ io.Reader }{f}, buf); err != nil {
+				b.Fatal(err)
+			}
+		}
+		r.Close()
+	}
+}
+
+func BenchmarkReadFiles(b *testing.B) {
+	benchmarkReadFiles(b, mpq.OpenReader)
+}
+
+func BenchmarkReadFilesMapped(b *testing.B) {
+	benchmarkReadFiles(b, mpq.OpenMapped)
+}
+
+func BenchmarkNewReader(b *testing.B) {
+	// 30000 files gives a hash table of 65536 entries.
+	files := make([]mpqtest.File, 30000)
+	for i := range files {
+		files[i] = mpqtest.File{Name: fmt.Sprintf("file%d", i), Data: []byte{byte(i)}}
+	}
+	data := mpqtest.Build(nil, files...)
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := mpq.NewReader(bytes.NewReader(data), int64(len(data))); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecrypter(b *testing.B) {
+	data := testData(1 << 20)
+	buf := make([]byte, len(data))
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		d := mpq.NewDecrypter(bytes.NewReader(data), 0x12345678)
+		if _, err := io.ReadFull(d, buf); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestTruncated(t *testing.T) {
+	data := mpqtest.Build([]byte("replay header"), testFiles...)
+	for n := 0; n < len(data); n++ {
+		if _, err := mpq.NewReader(bytes.NewReader(data[:n]), int64(n)); err == nil {
+			t.Errorf("NewReader of %d-byte prefix succeeded", n)
+		}
+	}
+
+	// A user data block larger than the archive must fail before its
+	// data is allocated.
+	var huge bytes.Buffer
+	huge.WriteString("MPQ\x1b")
+	binary.Write(&huge, binary.LittleEndian, []uint32{0xfffffff0, 16, 0xfffffff0})
+	huge.Write(make([]byte, 80-huge.Len()))
+	if _, err := mpq.NewReader(bytes.NewReader(huge.Bytes()), int64(huge.Len())); err == nil || !strings.Contains(err.Error(), "user data block") {
+		t.Errorf("NewReader of oversized user data: got %v", err)
+	}
+}
+
+func addArchiveSeeds(f *testing.F) {
+	f.Add(mpqtest.Build(nil, testFiles...))
+	f.Add(mpqtest.Build([]byte("replay header"), testFiles...))
+	f.Add(mpqtest.Build(nil))
+}
+
+func FuzzNewReader(f *testing.F) {
+	addArchiveSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		r, err := mpq.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return
+		}
+		r.GetFileList()
+	})
+}
+
+func FuzzOpenFile(f *testing.F) {
+	addArchiveSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		r, err := mpq.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return
+		}
+		for name := range testContents {
+			fr, err := r.OpenFile(name)
+			if err != nil {
+				continue
+			}
+			io.Copy(io.Discard, fr)
+			fr.ReadAt(make([]byte, 16), fr.Size()/2)
+		}
+	})
+}
+
+// TestDecrypterPartial checks that reads smaller than a block carry
+// the rest of the block over to the next read.
+func TestDecrypterPartial(t *testing.T) {
+	data := testData(12)
+	mpqtest.Encrypt(data, 1234)
+	d := mpq.NewDecrypter(bytes.NewReader(data), 1234)
+	var got []byte
+	for _, size := range []int{2, 3, 1, 5, 1} {
+		buf := make([]byte, size)
+		n, err := d.Read(buf)
+		if err != nil || n != size {
+			t.Fatalf("Read(%d) returned %d, %v", size, n, err)
+		}
+		got = append(got, buf...)
+	}
+	if !bytes.Equal(got, testData(12)) {
+		t.Errorf("got %x, expected %x", got, testData(12))
+	}
+	if n, err := d.Read(make([]byte, 4)); n != 0 || err != io.EOF {
+		t.Errorf("Read at end returned %d, %v", n, err)
+	}
+}
+
+func FuzzDecrypter(f *testing.F) {
+	f.Add([]byte("0123456789abcdef"), uint32(0), 3)
+	f.Add(mpqtest.Build(nil, testFiles...), mpq.Hash("(hash table)", mpq.HashFileKey), 16)
+	f.Fuzz(func(t *testing.T, data []byte, key uint32, chunk int) {
+		whole, err := io.ReadAll(mpq.NewDecrypter(bytes.NewReader(data), key))
+		if err != io.ErrUnexpectedEOF && err != nil {
+			t.Fatal(err)
+		}
+		if len(whole) != len(data)&^3 {
+			t.Fatalf("decrypted %d bytes of %d", len(whole), len(data))
+		}
+		plain := append([]byte(nil), whole...)
+		mpqtest.Encrypt(plain, key)
+		if !bytes.Equal(plain, data[:len(whole)]) {
+			t.Fatalf("decryption doesn't round trip")
+		}
+
+		if chunk <= 0 || chunk > 64 {
+			chunk = 1 + chunk&63
+		}
+		d := mpq.NewDecrypter(bytes.NewReader(data), key)
+		var pieces []byte
+		buf := make([]byte, chunk)
+		for {
+			n, err := d.Read(buf)
+			pieces = append(pieces, buf[:n]...)
+			if err != nil {
+				break
+			}
+		}
+		if !bytes.Equal(pieces, whole) {
+			t.Fatalf("reading in %d-byte chunks gave different output", chunk)
+		}
+	})
+}
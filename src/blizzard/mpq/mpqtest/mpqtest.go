@@ -0,0 +1,121 @@
+// Package mpqtest builds small MPQ archives for tests of packages that
+// read them.
+package mpqtest
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"blizzard/mpq"
+)
+
+// File is a file to store in an archive.
+type File struct {
+	Name string
+	Data []byte
+
+	// Flags, if nonzero, are the file's block flags, with Data as it is
+	// stored, such as compressed, and Size its uncompressed size.  By
+	// default Data is stored as is.
+	Flags uint32
+	Size  int
+}
+
+// encryptTable holds the part of the MPQ crypt table used for
+// encryption, which the mpq package does not export.
+var encryptTable [0x100]uint32
+
+func init() {
+	seed := uint32(0x00100001)
+	for index1 := 0; index1 < 0x100; index1++ {
+		for i := 0; i < 5; i++ {
+			seed = (seed*125 + 3) % 0x2AAAAB
+			temp1 := (seed & 0xFFFF) << 0x10
+			seed = (seed*125 + 3) % 0x2AAAAB
+			temp2 := (seed & 0xFFFF)
+			if i == 4 {
+				encryptTable[index1] = temp1 | temp2
+			}
+		}
+	}
+}
+
+// Encrypt encrypts the whole 4-byte blocks of buf in place with key,
+// as the hash and block tables of an archive are stored.  A trailing
+// partial block is left as is.
+func Encrypt(buf []byte, key uint32) {
+	seed := uint32(0xeeeeeeee)
+	for i := 0; i+4 <= len(buf); i += 4 {
+		seed += encryptTable[key&0xFF]
+		plain := binary.LittleEndian.Uint32(buf[i:])
+		binary.LittleEndian.PutUint32(buf[i:], plain^(key+seed))
+		key = ((^key << 0x15) + 0x11111111) | (key >> 0xB)
+		seed = plain + seed + (seed << 5) + 3
+	}
+}
+
+// Build returns a version 0 archive holding files, preceded by a user
+// data block if userData is non-nil.
+func Build(userData []byte, files ...File) []byte {
+	var buf bytes.Buffer
+	le := func(v interface{}) { binary.Write(&buf, binary.LittleEndian, v) }
+
+	headerOfs := 0
+	if userData != nil {
+		headerOfs = (16 + len(userData) + 0x1ff) &^ 0x1ff
+		buf.WriteString("MPQ\x1b")
+		le([]uint32{uint32(headerOfs - 16), uint32(headerOfs), uint32(len(userData))})
+		buf.Write(userData)
+		buf.Write(make([]byte, headerOfs-buf.Len()))
+	}
+
+	const headerSize = 32
+	var data bytes.Buffer
+	blocks := make([]uint32, 0, 4*len(files))
+	for _, f := range files {
+		flags, size := uint32(mpq.BlockFlagFile), len(f.Data)
+		if f.Flags != 0 {
+			flags, size = f.Flags, f.Size
+		}
+		blocks = append(blocks, uint32(headerSize+data.Len()), uint32(len(f.Data)), uint32(size), flags)
+		data.Write(f.Data)
+	}
+
+	hashEntries := 16
+	for hashEntries < 2*len(files) {
+		hashEntries *= 2
+	}
+	hashes := make([]uint32, 4*hashEntries)
+	for i := range hashes {
+		hashes[i] = 0xffffffff
+	}
+	for i, f := range files {
+		index := mpq.Hash(f.Name, mpq.HashTableOffset) & uint32(hashEntries-1)
+		for hashes[4*index+3] != 0xffffffff {
+			index = (index + 1) % uint32(hashEntries)
+		}
+		hashes[4*index] = mpq.Hash(f.Name, mpq.HashNameA)
+		hashes[4*index+1] = mpq.Hash(f.Name, mpq.HashNameB)
+		hashes[4*index+2] = 0
+		hashes[4*index+3] = uint32(i)
+	}
+
+	hashTableOfs := headerSize + data.Len()
+	blockTableOfs := hashTableOfs + 16*hashEntries
+	buf.WriteString("MPQ\x1a")
+	le([]uint32{headerSize, uint32(blockTableOfs + 16*len(files))})
+	le([]uint16{0, 3})
+	le([]uint32{uint32(hashTableOfs), uint32(blockTableOfs), uint32(hashEntries), uint32(len(files))})
+	buf.Write(data.Bytes())
+
+	var table bytes.Buffer
+	binary.Write(&table, binary.LittleEndian, hashes)
+	Encrypt(table.Bytes(), mpq.Hash("(hash table)", mpq.HashFileKey))
+	buf.Write(table.Bytes())
+
+	table.Reset()
+	binary.Write(&table, binary.LittleEndian, blocks)
+	Encrypt(table.Bytes(), mpq.Hash("(block table)", mpq.HashFileKey))
+	buf.Write(table.Bytes())
+	return buf.Bytes()
+}
@@ -0,0 +1,38 @@
+package mpqtest
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"blizzard/mpq"
+)
+
+func TestBuild(t *testing.T) {
+	files := []File{
+		{Name: "a.txt", Data: []byte("hello")},
+		{Name: "dir\\b", Data: bytes.Repeat([]byte("x"), 10000)},
+		{Name: "empty"},
+	}
+	archive := Build([]byte("user"), files...)
+	r, err := mpq.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(r.UserData()) != "user" {
+		t.Errorf("user data %q", r.UserData())
+	}
+	for _, f := range files {
+		fr, err := r.OpenFile(f.Name)
+		if err != nil {
+			t.Fatalf("%s: %s", f.Name, err)
+		}
+		data, err := io.ReadAll(fr)
+		if err != nil {
+			t.Fatalf("%s: %s", f.Name, err)
+		}
+		if !bytes.Equal(data, f.Data) {
+			t.Errorf("%s: got %d bytes, expected %d", f.Name, len(data), len(f.Data))
+		}
+	}
+}
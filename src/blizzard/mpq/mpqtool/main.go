@@ -19,15 +19,19 @@ func main() {
 	}
 	path, command, args := os.Args[1], os.Args[2], os.Args[3:]
 
-	f, err := os.Open(path)
+	r, err := mpq.OpenReader(path)
 	if err != nil {
 		log.Fatal(err)
 	}
-	r := mpq.NewReader(f)
+	defer r.Close()
 
 	switch command {
 	case "ls":
-		for _, f := range r.GetFileList() {
+		files, err := r.GetFileList()
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, f := range files {
 			fmt.Printf("%s\n", f)
 		}
 	case "cat":
@@ -36,8 +40,16 @@ func main() {
 		}
 		path = args[0]
 
-		f := r.OpenFile(path)
-		_, err := io.Copy(os.Stdout, f)
+		f, err := r.OpenFile(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		_, err = io.Copy(os.Stdout, f)
+		if err != nil {
+			panic(err)
+		}
+	case "userdata":
+		_, err := os.Stdout.Write(r.UserData())
 		if err != nil {
 			panic(err)
 		}
@@ -0,0 +1,162 @@
+package replay
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// replay.attributes.events holds the lobby's settings for each player
+// and for the game as a whole, as a little-endian list of attributes
+// with four-character values.
+
+// GlobalScope is the scope of attributes of the game rather than of a
+// player.  Players' scopes are numbered from 1.
+const GlobalScope = 16
+
+// AttributeId identifies an attribute.
+type AttributeId uint32
+
+const (
+	AttrPlayerType AttributeId = 500
+	AttrTeams      AttributeId = 2001
+	AttrGameSpeed  AttributeId = 3000
+	AttrRace       AttributeId = 3001
+	AttrColor      AttributeId = 3002
+	AttrHandicap   AttributeId = 3003
+	AttrDifficulty AttributeId = 3004
+	AttrGameMode   AttributeId = 3009
+	AttrHero       AttributeId = 4002
+	AttrSkin       AttributeId = 4003
+	AttrMount      AttributeId = 4004
+)
+
+// AttributeInfo describes a well-known attribute.
+type AttributeInfo struct {
+	Name string
+	// Values names the attribute's values.  Values not in it, or all
+	// values if it is nil, stand for themselves.
+	Values map[string]string
+}
+
+// KnownAttributes describes well-known attributes.  Callers may add
+// further attributes to it.
+var KnownAttributes = map[AttributeId]*AttributeInfo{
+	AttrPlayerType: {"Player Type", map[string]string{
+		"Humn": "Human", "Comp": "Computer", "Open": "Open", "Clsd": "Closed",
+	}},
+	AttrTeams: {"Teams", map[string]string{"Cust": "Custom"}},
+	AttrGameSpeed: {"Game Speed", map[string]string{
+		"Slor": "Slower", "Slow": "Slow", "Norm": "Normal", "Fast": "Fast", "Fasr": "Faster",
+	}},
+	AttrRace: {"Race", map[string]string{
+		"Prot": "Protoss", "Terr": "Terran", "Zerg": "Zerg", "RAND": "Random",
+	}},
+	AttrColor:    {"Color", nil},
+	AttrHandicap: {"Handicap", nil},
+	AttrDifficulty: {"Difficulty", map[string]string{
+		"VyEy": "Very Easy", "Easy": "Easy", "Medi": "Medium",
+		"Hard": "Hard", "VyHd": "Very Hard", "Insa": "Insane",
+	}},
+	AttrGameMode: {"Game Mode", map[string]string{
+		"Priv": "Private", "Pub": "Public", "Amm": "Automated Match Making",
+	}},
+	AttrHero:  {"Hero", nil},
+	AttrSkin:  {"Skin", nil},
+	AttrMount: {"Mount", nil},
+}
+
+func (id AttributeId) String() string {
+	if info := KnownAttributes[id]; info != nil {
+		return info.Name
+	}
+	return fmt.Sprintf("AttributeId(%d)", uint32(id))
+}
+
+// Attribute is a single setting of the lobby.
+type Attribute struct {
+	Namespace uint32
+	Id        AttributeId
+	Scope     int
+	Value     string // with padding removed
+}
+
+// ValueName returns the name of the attribute's value, if it is known,
+// or else the value itself.
+func (a *Attribute) ValueName() string {
+	if info := KnownAttributes[a.Id]; info != nil {
+		if name, ok := info.Values[a.Value]; ok {
+			return name
+		}
+	}
+	return a.Value
+}
+
+// Attributes holds the contents of replay.attributes.events.
+type Attributes struct {
+	Source       int
+	MapNamespace uint32
+	Attributes   []*Attribute // in file order
+}
+
+// Scope returns the attributes of a scope, such as a player's or
+// GlobalScope, by id.
+func (a *Attributes) Scope(scope int) map[AttributeId]*Attribute {
+	attrs := map[AttributeId]*Attribute{}
+	for _, attr := range a.Attributes {
+		if attr.Scope == scope {
+			attrs[attr.Id] = attr
+		}
+	}
+	return attrs
+}
+
+// Global returns the attributes of the game as a whole, by id.
+func (a *Attributes) Global() map[AttributeId]*Attribute {
+	return a.Scope(GlobalScope)
+}
+
+// Players returns the attributes of each player scope, by id.
+func (a *Attributes) Players() map[int]map[AttributeId]*Attribute {
+	players := map[int]map[AttributeId]*Attribute{}
+	for _, attr := range a.Attributes {
+		if attr.Scope == GlobalScope {
+			continue
+		}
+		if players[attr.Scope] == nil {
+			players[attr.Scope] = map[AttributeId]*Attribute{}
+		}
+		players[attr.Scope][attr.Id] = attr
+	}
+	return players
+}
+
+// ReadAttributes decodes the contents of replay.attributes.events.
+func ReadAttributes(data []byte) (*Attributes, error) {
+	a := &Attributes{}
+	if len(data) == 0 {
+		return a, nil
+	}
+	// The header is followed by a count of attributes, which is
+	// ignored in favor of the file's length.
+	const headerSize, attrSize = 1 + 4 + 4, 4 + 4 + 1 + 4
+	if len(data) < headerSize || (len(data)-headerSize)%attrSize != 0 {
+		return nil, fmt.Errorf("replay: attributes: %w", io.ErrUnexpectedEOF)
+	}
+	a.Source = int(data[0])
+	a.MapNamespace = binary.LittleEndian.Uint32(data[1:])
+	for data = data[headerSize:]; len(data) > 0; data = data[attrSize:] {
+		value := append([]byte(nil), data[9:13]...)
+		for i, j := 0, len(value)-1; i < j; i, j = i+1, j-1 {
+			value[i], value[j] = value[j], value[i]
+		}
+		a.Attributes = append(a.Attributes, &Attribute{
+			Namespace: binary.LittleEndian.Uint32(data),
+			Id:        AttributeId(binary.LittleEndian.Uint32(data[4:])),
+			Scope:     int(data[8]),
+			Value:     strings.Trim(string(value), "\x00"),
+		})
+	}
+	return a, nil
+}
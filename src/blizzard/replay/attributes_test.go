@@ -0,0 +1,63 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func attribute(id AttributeId, scope int, value string) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, uint32(999))
+	binary.Write(&buf, binary.LittleEndian, uint32(id))
+	buf.WriteByte(byte(scope))
+	v := []byte(value + "\x00\x00\x00\x00")[:4]
+	for i := 3; i >= 0; i-- {
+		buf.WriteByte(v[i])
+	}
+	return buf.Bytes()
+}
+
+func TestReadAttributes(t *testing.T) {
+	data := []byte("\x01\x39\x30\x00\x00\x04\x00\x00\x00")
+	data = append(data, attribute(AttrPlayerType, 1, "Humn")...)
+	data = append(data, attribute(AttrHero, 1, "Zera")...)
+	data = append(data, attribute(AttrPlayerType, 2, "Comp")...)
+	data = append(data, attribute(AttrGameMode, GlobalScope, "Amm")...)
+	data = append(data, attribute(9999, GlobalScope, "x")...)
+
+	a, err := ReadAttributes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Source != 1 || a.MapNamespace != 12345 || len(a.Attributes) != 5 {
+		t.Errorf("bad attributes %+v", a)
+	}
+	players := a.Players()
+	if len(players) != 2 {
+		t.Fatalf("got %d players, expected 2", len(players))
+	}
+	if attr := players[1][AttrHero]; attr == nil || attr.Value != "Zera" || attr.Namespace != 999 || attr.ValueName() != "Zera" {
+		t.Errorf("bad hero %+v", attr)
+	}
+	if attr := players[2][AttrPlayerType]; attr == nil || attr.ValueName() != "Computer" {
+		t.Errorf("bad player type %+v", attr)
+	}
+	global := a.Global()
+	if attr := global[AttrGameMode]; attr == nil || attr.Value != "Amm" || attr.ValueName() != "Automated Match Making" {
+		t.Errorf("bad game mode %+v", attr)
+	}
+	if attr := global[9999]; attr == nil || attr.Id.String() != "AttributeId(9999)" || attr.ValueName() != "x" {
+		t.Errorf("bad unknown attribute %+v", attr)
+	}
+	if AttrRace.String() != "Race" {
+		t.Errorf("got %q, expected Race", AttrRace)
+	}
+
+	if a, err := ReadAttributes(nil); err != nil || len(a.Attributes) != 0 {
+		t.Errorf("empty file: got %+v, %v", a, err)
+	}
+	if _, err := ReadAttributes(data[:len(data)-1]); err == nil {
+		t.Errorf("decoded truncated attributes")
+	}
+}
@@ -0,0 +1,68 @@
+package replay
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// BitArray is a set of bits of arbitrary length, such as the colors
+// allowed in a lobby slot.  Bit 0 is the last bit of the encoding.
+type BitArray struct {
+	Len   int
+	words []uint64 // least significant first
+}
+
+// Bit reports whether bit i is set.
+func (b BitArray) Bit(i int) bool {
+	if i < 0 || i >= b.Len {
+		return false
+	}
+	return b.words[i/64]>>uint(i%64)&1 != 0
+}
+
+// Count returns the number of bits set.
+func (b BitArray) Count() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// String returns the bits as binary digits, most significant first.
+func (b BitArray) String() string {
+	var s strings.Builder
+	for i := b.Len - 1; i >= 0; i-- {
+		if b.Bit(i) {
+			s.WriteByte('1')
+		} else {
+			s.WriteByte('0')
+		}
+	}
+	return s.String()
+}
+
+// readBitArray reads a bit array of n bits, most significant first.
+func readBitArray(r *bitReader, n int) BitArray {
+	b := BitArray{Len: n, words: make([]uint64, (n+63)/64)}
+	for i := len(b.words) - 1; i >= 0; i-- {
+		k := n - 64*i
+		if k > 64 {
+			k = 64
+		}
+		b.words[i] = readBits(r, k)
+	}
+	return b
+}
+
+// bitArrayFromBytes returns the bit array of n bits held in data, in
+// the versioned encoding: a big-endian number padded to whole bytes.
+func bitArrayFromBytes(n int, data []byte) BitArray {
+	b := BitArray{Len: n, words: make([]uint64, (n+63)/64)}
+	for i := 0; i < n && i/8 < len(data); i++ {
+		if data[len(data)-1-i/8]>>uint(i%8)&1 != 0 {
+			b.words[i/64] |= 1 << uint(i%64)
+		}
+	}
+	return b
+}
@@ -37,3 +37,57 @@ func TestBitReader(t *testing.T) {
 	// xxxx xxxx ^^^^ ^xxx
 	expectBits(t, 0xf, r, 5)
 }
+
+// bitWriter writes bits in the order bitReader reads them, for
+// building test streams.
+type bitWriter struct {
+	buf  []byte
+	used uint // bits used of the last byte, or 0 if it is full
+}
+
+func (w *bitWriter) WriteBits(val uint64, n int) {
+	for n > 0 {
+		if w.used == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		toCopy := min(8-w.used, uint(n))
+		chunk := uint8(val>>uint(n-int(toCopy))) & uint8((1<<toCopy)-1)
+		w.buf[len(w.buf)-1] |= chunk << w.used
+		w.used = (w.used + toCopy) % 8
+		n -= int(toCopy)
+	}
+}
+
+func (w *bitWriter) SyncToByte() {
+	w.used = 0
+}
+
+func (w *bitWriter) WriteBytes(b string) {
+	w.SyncToByte()
+	w.buf = append(w.buf, b...)
+}
+
+func TestBitWriter(t *testing.T) {
+	var w bitWriter
+	w.WriteBits(0x4, 3)
+	w.WriteBits(0xf, 4)
+	w.WriteBits(0x4, 4)
+	w.WriteBits(0xf, 5)
+	if string(w.buf) != "\x7c\x7c" {
+		t.Errorf("got %q, expected %q", w.buf, "\x7c\x7c")
+	}
+}
+
+func TestReadFourCC(t *testing.T) {
+	// A fourcc is not byte-aligned.
+	var w bitWriter
+	w.WriteBits(0x5, 3)
+	for _, c := range []byte("Hero") {
+		w.WriteBits(uint64(c), 8)
+	}
+	r := newBitReader(bufio.NewReader(strings.NewReader(string(w.buf))))
+	expectBits(t, 0x5, r, 3)
+	if f := readFourCC(r); f.String() != "Hero" {
+		t.Errorf("got %q, expected Hero", f)
+	}
+}
@@ -0,0 +1,22 @@
+package replay
+
+import "strings"
+
+// FourCC is a four-character code, such as the program id "Hero" of a
+// toon.
+type FourCC [4]byte
+
+// String returns the code with any NUL padding removed.
+func (f FourCC) String() string {
+	return strings.Trim(string(f[:]), "\x00")
+}
+
+// readFourCC reads a four-character code, which unlike blobs is not
+// byte-aligned.
+func readFourCC(r *bitReader) FourCC {
+	var f FourCC
+	for i := range f {
+		f[i] = byte(readBits(r, 8))
+	}
+	return f
+}
@@ -0,0 +1,1028 @@
+// Command gen generates the decoders of package replay from protocol
+// definitions.
+//
+// A protocol definition is a JSON file named for the base build it
+// describes, such as 34835.json.  It transcribes the variables of the
+// s2protocol module for that build:
+//
+//	typeinfos                a list of [kind, args], as in s2protocol
+//	game_event_types etc.    maps from event ids to [typeinfo id, name]
+//	game_eventid_typeid etc. ids of the typeinfos framing the files
+//
+// and adds "names", a map from typeinfo ids to names for types that
+// s2protocol leaves unnamed.
+//
+// Builds whose definitions agree on everything but the names share a
+// set of decoders, written to typeinfoNNNNN.go for the first of the
+// builds, and the names may be given in any one of them.  The Go types
+// are shared by all builds and written to typeinfo.go: a struct type
+// has the fields of the structs of that name in every build, with
+// integers as wide as the widest build's, and fields a build lacks are
+// left zero.  Structs that are not named explicitly, by the names or
+// as events, are instead matched by their fields.  To support a new
+// build, add its definition and rerun go generate.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// unmarshalTuple decodes a JSON array into vs, one element each.
+func unmarshalTuple(data []byte, vs ...interface{}) error {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	if len(elems) != len(vs) {
+		return fmt.Errorf("got %d elements, expected %d", len(elems), len(vs))
+	}
+	for i, v := range vs {
+		if err := json.Unmarshal(elems[i], v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type bounds struct {
+	Offset, Bits int64
+}
+
+func (b *bounds) UnmarshalJSON(data []byte) error {
+	return unmarshalTuple(data, &b.Offset, &b.Bits)
+}
+
+type field struct {
+	Name string
+	Type int
+	Tag  int
+}
+
+func (f *field) UnmarshalJSON(data []byte) error {
+	return unmarshalTuple(data, &f.Name, &f.Type, &f.Tag)
+}
+
+type choiceValue struct {
+	Name string
+	Type int
+}
+
+func (c *choiceValue) UnmarshalJSON(data []byte) error {
+	return unmarshalTuple(data, &c.Name, &c.Type)
+}
+
+type eventType struct {
+	Type int
+	Name string
+}
+
+func (e *eventType) UnmarshalJSON(data []byte) error {
+	return unmarshalTuple(data, &e.Type, &e.Name)
+}
+
+// typeInfo is an s2protocol typeinfo, and what the generator works out
+// about its Go type.
+type typeInfo struct {
+	Kind    string // without s2protocol's leading underscore
+	Bounds  bounds // of ints, lengths, and choice tags
+	Type    int    // element of an array or optional
+	Fields  []field
+	Choices map[int]choiceValue
+
+	id        int
+	name      string
+	derived   bool     // name is made up from hints or the id
+	names     []string // of the events it is, sorted
+	nameHints map[string]bool
+	typ       string
+	event     bool
+}
+
+func (t *typeInfo) UnmarshalJSON(data []byte) error {
+	var kind string
+	var args json.RawMessage
+	if err := unmarshalTuple(data, &kind, &args); err != nil {
+		return err
+	}
+	t.Kind = strings.TrimPrefix(kind, "_")
+	switch t.Kind {
+	case "int", "blob", "bitarray":
+		return unmarshalTuple(args, &t.Bounds)
+	case "array":
+		return unmarshalTuple(args, &t.Bounds, &t.Type)
+	case "optional":
+		return unmarshalTuple(args, &t.Type)
+	case "struct":
+		return unmarshalTuple(args, &t.Fields)
+	case "choice":
+		return unmarshalTuple(args, &t.Bounds, &t.Choices)
+	case "bool", "null", "fourcc":
+		return unmarshalTuple(args)
+	}
+	return fmt.Errorf("unknown typeinfo kind %q", kind)
+}
+
+// layout is the part of a protocol definition that decoders depend on.
+type layout struct {
+	Typeinfos         []*typeInfo       `json:"typeinfos"`
+	GameEventTypes    map[int]eventType `json:"game_event_types"`
+	MessageEventTypes map[int]eventType `json:"message_event_types"`
+	TrackerEventTypes map[int]eventType `json:"tracker_event_types"`
+
+	GameEventIdTypeid    int `json:"game_eventid_typeid"`
+	MessageEventIdTypeid int `json:"message_eventid_typeid"`
+	TrackerEventIdTypeid int `json:"tracker_eventid_typeid"`
+	Svaruint32Typeid     int `json:"svaruint32_typeid"`
+	ReplayUserIdTypeid   int `json:"replay_userid_typeid"`
+	ReplayHeaderTypeid   int `json:"replay_header_typeid"`
+	GameDetailsTypeid    int `json:"game_details_typeid"`
+	ReplayInitDataTypeid int `json:"replay_initdata_typeid"`
+}
+
+type protocolDef struct {
+	layout
+	Names map[int]string `json:"names"`
+}
+
+// A group is the builds of one layout, which share a set of decoders.
+type group struct {
+	*layout
+	names  map[int]string // given by any of the builds
+	builds []int          // in increasing order
+}
+
+// read reads the protocol definitions in dir, returning them grouped
+// by layout, in increasing order of their first builds.
+func read(dir string) ([]*group, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	defs := map[int]*protocolDef{}
+	var builds []int
+	for _, path := range paths {
+		build, err := strconv.Atoi(strings.TrimSuffix(filepath.Base(path), ".json"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: file name is not a build number", path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		def := &protocolDef{}
+		if err := json.Unmarshal(data, def); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		defs[build] = def
+		builds = append(builds, build)
+	}
+	if len(builds) == 0 {
+		return nil, fmt.Errorf("%s: no protocol definitions", dir)
+	}
+	sort.Ints(builds)
+
+	var groups []*group
+	for _, build := range builds {
+		def := defs[build]
+		var gr *group
+		for _, other := range groups {
+			if reflect.DeepEqual(&def.layout, other.layout) {
+				gr = other
+				break
+			}
+		}
+		if gr == nil {
+			gr = &group{layout: &def.layout, names: map[int]string{}}
+			groups = append(groups, gr)
+		}
+		gr.builds = append(gr.builds, build)
+		for id, name := range def.Names {
+			if gr.names[id] != "" && gr.names[id] != name {
+				return nil, fmt.Errorf("protocol %d names typeinfo %d %s, not %s", build, id, name, gr.names[id])
+			}
+			gr.names[id] = name
+		}
+	}
+	return groups, nil
+}
+
+func fieldToGo(name string) string {
+	name = strings.TrimPrefix(name, "m_")
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func simpleName(name string) string {
+	for _, prefix := range []string{"NNet.Game.S", "NNet.Replay.Tracker.S"} {
+		if strings.HasPrefix(name, prefix) {
+			return name[len(prefix):]
+		}
+	}
+	return name
+}
+
+func genReadInt(b bounds) string {
+	switch {
+	case b.Offset == 0:
+		return fmt.Sprintf("readBits(r, %d)", b.Bits)
+	case b.Bits == 0:
+		return fmt.Sprintf("%d", b.Offset)
+	}
+	return fmt.Sprintf("%d + int64(readBits(r, %d))", b.Offset, b.Bits)
+}
+
+// sortedKeys returns the keys of a map with int keys in increasing
+// order.
+func sortedKeys(m interface{}) []int {
+	var keys []int
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, int(k.Int()))
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+type gen struct {
+	bytes.Buffer
+	typeinfos []*typeInfo
+	group     *group
+	recv      string // the type whose methods the decoders are
+	types     *types
+	claimed   map[string]bool // struct names given to typeinfos
+}
+
+func (g *gen) Print(s string, args ...interface{}) {
+	fmt.Fprintf(g, s+"\n", args...)
+}
+
+func (t *typeInfo) isEmptyStruct() bool {
+	return t.Kind == "struct" && len(t.Fields) == 0
+}
+
+// decodeCode returns an expression decoding t from the bit-packed
+// reader r, as the type named name if t has several.
+func (t *typeInfo) decodeCode(name string) string {
+	if name == "" {
+		name = t.name
+	}
+	switch {
+	case t.Kind == "int":
+		return fmt.Sprintf("%s(%s)", t.typ, genReadInt(t.Bounds))
+	case t.Kind == "bool":
+		return "readBits(r, 1) != 0"
+	case t.Kind == "null":
+		return "nil"
+	case t.isEmptyStruct():
+		return fmt.Sprintf("&%s{}", name)
+	}
+	return fmt.Sprintf("p.decode%s(r)", name)
+}
+
+// versionedDecodeCode returns an expression decoding t from the token
+// tok of the versioned decoder d.
+func (t *typeInfo) versionedDecodeCode(tok string) string {
+	switch t.Kind {
+	case "int":
+		return fmt.Sprintf("%s(d.int(%s))", t.typ, tok)
+	case "bool":
+		return fmt.Sprintf("d.bool(%s)", tok)
+	case "null":
+		return "nil"
+	}
+	return fmt.Sprintf("p.decodeVersioned%s(d, %s)", t.name, tok)
+}
+
+// nameTypes assigns Go names and types to the typeinfos.
+func (g *gen) nameTypes(l *layout, names map[int]string) {
+	for _, ets := range []map[int]eventType{l.GameEventTypes, l.MessageEventTypes, l.TrackerEventTypes} {
+		for _, et := range ets {
+			t := g.typeinfos[et.Type]
+			t.names = append(t.names, simpleName(et.Name))
+			t.event = true
+		}
+	}
+	for _, t := range g.typeinfos {
+		sort.Strings(t.names)
+	}
+
+	g.typeinfos[l.Svaruint32Typeid].name = "GameLoopDeltaAuto"
+	g.typeinfos[l.Svaruint32Typeid].typ = "int32"
+	g.typeinfos[l.ReplayHeaderTypeid].name = "Header"
+	g.typeinfos[l.GameDetailsTypeid].name = "Details"
+	g.typeinfos[l.ReplayInitDataTypeid].name = "InitData"
+	for id, name := range names {
+		g.typeinfos[id].name = name
+	}
+
+	for _, t := range g.typeinfos {
+		switch t.Kind {
+		case "int":
+			if t.name == "" {
+				switch {
+				case t.Bounds.Offset != 0:
+					t.name = fmt.Sprintf("Int%d_%d", t.Bounds.Bits, t.id)
+				case t.Bounds.Bits <= 8:
+					t.typ = "int8"
+				case t.Bounds.Bits <= 16:
+					t.typ = "int16"
+				case t.Bounds.Bits <= 32:
+					t.typ = "int32"
+				}
+			}
+			if t.typ == "" {
+				t.typ = "int64"
+			}
+		case "choice":
+			if t.typ == "" {
+				t.typ = "interface{}"
+			}
+		case "blob":
+			t.name = fmt.Sprintf("ByteString_%d_%d", t.Bounds.Offset, t.Bounds.Bits)
+			t.typ = "string"
+		case "struct":
+			for _, f := range t.Fields {
+				ft := g.typeinfos[f.Type]
+				if ft.nameHints == nil {
+					ft.nameHints = map[string]bool{}
+				}
+				ft.nameHints[fieldToGo(f.Name)] = true
+			}
+		case "bool":
+			t.typ = "bool"
+		case "bitarray":
+			t.typ = "BitArray"
+		case "fourcc":
+			t.typ = "FourCC"
+		case "null":
+			// Null values are always nil.
+			t.typ = "interface{}"
+		}
+	}
+
+	for _, t := range g.typeinfos {
+		switch t.Kind {
+		case "struct":
+			if t.name == "" && len(t.names) > 0 {
+				t.name = t.names[0]
+			}
+			if t.name == "" {
+				t.derived = true
+				if hints := t.sortedHints(); len(hints) > 0 {
+					t.name = hints[0]
+				} else {
+					t.name = fmt.Sprintf("Unknown%d", t.id)
+				}
+				t.name = g.types.match(t.name, g.fields(t), g.group.builds[0], g.claimed)
+			}
+			g.claimed[t.name] = true
+			t.typ = "*" + t.name
+		case "optional":
+			t.typ = "*" + g.typeinfos[t.Type].typ
+		case "array":
+			t.typ = "[]" + g.typeinfos[t.Type].typ
+		}
+	}
+
+	// The other decoders are named after their ids, unless a struct
+	// matched to another protocol's has taken the name.
+	for _, t := range g.typeinfos {
+		if t.name == "" {
+			t.name = fmt.Sprintf("Unknown%d", t.id)
+			if g.claimed[t.name] {
+				t.name = fmt.Sprintf("Unknown%d_%d", t.id, g.group.builds[0])
+			}
+		}
+	}
+}
+
+func (t *typeInfo) sortedHints() []string {
+	var hints []string
+	for hint := range t.nameHints {
+		hints = append(hints, hint)
+	}
+	sort.Strings(hints)
+	return hints
+}
+
+// goNames returns the names of the Go types of t: one per event type if
+// it is an event, and otherwise just its name.
+func (t *typeInfo) goNames() []string {
+	if len(t.names) > 0 {
+		return t.names
+	}
+	return []string{t.name}
+}
+
+// goType returns the Go type of t as the type named name.
+func (t *typeInfo) goType(name string) string {
+	if len(t.names) > 0 {
+		return "*" + name
+	}
+	return t.typ
+}
+
+// genDecode emits the decoder of t, named name, for the bit-packed
+// encoding used by the game and message events and replay.initData.
+func (g *gen) genDecode(t *typeInfo, name string) {
+	g.Print("func (p %s) decode%s(r *bitReader) %s {", g.recv, name, t.goType(name))
+	switch t.Kind {
+	case "choice":
+		g.Print("switch tag := %s; tag {", genReadInt(t.Bounds))
+		for _, tag := range sortedKeys(t.Choices) {
+			c := t.Choices[tag]
+			decode := g.typeinfos[c.Type].decodeCode("")
+			g.Print("case %d: // %s", tag, c.Name)
+			if t.typ == "interface{}" {
+				g.Print("return %s", decode)
+			} else {
+				g.Print("return %s(%s)", t.typ, decode)
+			}
+		}
+		g.Print("default: panic(fmt.Errorf(\"unknown choice tag %%d\", tag))")
+		g.Print("}")
+	case "struct":
+		g.Print("out := &%s{}", name)
+		for _, f := range t.Fields {
+			g.Print("out.%s", g.assign(name, f, g.typeinfos[f.Type].decodeCode("")))
+		}
+		g.Print("return out")
+	case "blob":
+		g.Print("n := %s", genReadInt(t.Bounds))
+		g.Print("r.SyncToByte()")
+		g.Print("buf := make([]byte, n)")
+		g.Print("_, err := io.ReadFull(r, buf)")
+		g.Print("if err != nil { panic(err) }")
+		g.Print("return string(buf)")
+	case "array":
+		elem := g.typeinfos[t.Type]
+		g.Print("n := int(%s)", genReadInt(t.Bounds))
+		g.Print("arr := make([]%s, n)", elem.typ)
+		g.Print("for i := 0; i < n; i++ {")
+		g.Print("arr[i] = %s", elem.decodeCode(""))
+		g.Print("}")
+		g.Print("return arr")
+	case "optional":
+		g.Print("if readBits(r, 1) != 0 {")
+		g.Print("ret := %s", g.typeinfos[t.Type].decodeCode(""))
+		g.Print("return &ret")
+		g.Print("}")
+		g.Print("return nil")
+	case "bitarray":
+		g.Print("return readBitArray(r, int(%s))", genReadInt(t.Bounds))
+	case "fourcc":
+		g.Print("return readFourCC(r)")
+	default:
+		panic(fmt.Errorf("typeinfo %d: kind %s", t.id, t.Kind))
+	}
+	g.Print("}")
+}
+
+// genVersioned emits the decoder of t, named name, for the versioned
+// encoding used by the header, replay.details and the tracker events,
+// which tags each value with its type and each struct field with its
+// field tag.
+func (g *gen) genVersioned(t *typeInfo, name string) {
+	g.Print("func (p %s) decodeVersioned%s(d *versionedDecoder, tok blizzval.Token) %s {", g.recv, name, t.goType(name))
+	switch t.Kind {
+	case "choice":
+		g.Print("switch tag := d.choice(tok); tag {")
+		for _, tag := range sortedKeys(t.Choices) {
+			c := t.Choices[tag]
+			ct := g.typeinfos[c.Type]
+			g.Print("case %d: // %s", tag, c.Name)
+			if ct.Kind == "null" {
+				// A null variant is encoded as nothing.
+				g.Print("d.endChoice()")
+				g.Print("return nil")
+				continue
+			}
+			decode := ct.versionedDecodeCode("d.token()")
+			if t.typ == "interface{}" {
+				g.Print("return %s", decode)
+			} else {
+				g.Print("return %s(%s)", t.typ, decode)
+			}
+		}
+		g.Print("default: panic(fmt.Errorf(\"unknown choice tag %%d\", tag))")
+		g.Print("}")
+	case "struct":
+		g.Print("out := &%s{}", name)
+		g.Print("d.structStart(tok)")
+		g.Print("for tok, ok := d.field(); ok; tok, ok = d.field() {")
+		g.Print("switch tok.Key {")
+		for _, f := range t.Fields {
+			g.Print("case %d:", f.Tag)
+			g.Print("out.%s", g.assign(name, f, g.typeinfos[f.Type].versionedDecodeCode("tok")))
+		}
+		g.Print("default: d.skip(tok)")
+		g.Print("}")
+		g.Print("}")
+		g.Print("return out")
+	case "blob":
+		g.Print("return d.blob(tok)")
+	case "array":
+		elem := g.typeinfos[t.Type]
+		g.Print("n := d.array(tok)")
+		g.Print("arr := make([]%s, n)", elem.typ)
+		g.Print("for i := 0; i < n; i++ {")
+		g.Print("arr[i] = %s", elem.versionedDecodeCode("d.token()"))
+		g.Print("}")
+		g.Print("d.end()")
+		g.Print("return arr")
+	case "optional":
+		g.Print("if tok.Kind == blizzval.KindNull {")
+		g.Print("return nil")
+		g.Print("}")
+		g.Print("ret := %s", g.typeinfos[t.Type].versionedDecodeCode("tok"))
+		g.Print("return &ret")
+	case "fourcc":
+		g.Print("return d.fourCC(tok)")
+	case "bitarray":
+		g.Print("return d.bitArray(tok)")
+	default:
+		panic(fmt.Errorf("typeinfo %d: kind %s", t.id, t.Kind))
+	}
+	g.Print("}")
+}
+
+// eventIdBits returns the width of the event ids read with the int
+// typeinfo id.
+func (g *gen) eventIdBits(id int) int64 {
+	return g.typeinfos[id].Bounds.Bits
+}
+
+// userIdBits returns the width of the user ids of events, read with
+// typeinfo id: an int, or a struct of a single int field, m_userId.
+func (g *gen) userIdBits(id int) (int64, error) {
+	t := g.typeinfos[id]
+	if t.Kind == "struct" && len(t.Fields) == 1 {
+		t = g.typeinfos[t.Fields[0].Type]
+	}
+	if t.Kind != "int" || t.Bounds.Offset != 0 {
+		return 0, fmt.Errorf("user id typeinfo %d is not an unsigned int", id)
+	}
+	return t.Bounds.Bits, nil
+}
+
+// A typeDecl is a Go struct type, declared once for all protocols.
+type typeDecl struct {
+	name     string
+	build    int // the first build of the protocol declaring it
+	comments []string
+	event    bool
+	derived  bool
+	fields   []goField
+}
+
+type goField struct {
+	name, typ string
+	typeinfo  int
+	build     int // of the protocol whose typeinfo it is
+}
+
+// field returns the field of d named name.
+func (d *typeDecl) field(name string) *goField {
+	for i := range d.fields {
+		if d.fields[i].name == name {
+			return &d.fields[i]
+		}
+	}
+	return nil
+}
+
+// fields returns the Go fields of the struct typeinfo t, whose fields'
+// types must be known.
+func (g *gen) fields(t *typeInfo) []goField {
+	var fields []goField
+	for _, f := range t.Fields {
+		fields = append(fields, goField{fieldToGo(f.Name), g.typeinfos[f.Type].typ, f.Type, g.group.builds[0]})
+	}
+	return fields
+}
+
+// typeDecls returns the Go struct types of g's typeinfos, in order.
+func (g *gen) typeDecls() []*typeDecl {
+	var decls []*typeDecl
+	for _, t := range g.typeinfos {
+		if t.Kind != "struct" {
+			continue
+		}
+		comments := []string{fmt.Sprintf("typeinfo %d (%s)", t.id, t.Kind)}
+		if len(t.names) > 1 {
+			comments = append(comments, "names: "+strings.Join(t.names, ", "))
+		}
+		if hints := t.sortedHints(); len(t.names) == 0 && len(hints) > 1 {
+			comments = append(comments, "name hints: "+strings.Join(hints, ", "))
+		}
+		for _, name := range t.goNames() {
+			decls = append(decls, &typeDecl{
+				name:     name,
+				build:    g.group.builds[0],
+				comments: comments,
+				event:    t.event,
+				derived:  t.derived,
+				fields:   g.fields(t),
+			})
+		}
+	}
+	return decls
+}
+
+// sameFields reports whether a and b have the same fields.
+func sameFields(a, b []goField) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].name != b[i].name || a[i].typ != b[i].typ {
+			return false
+		}
+	}
+	return true
+}
+
+// types are the Go struct types shared by all protocols.
+type types struct {
+	decls    []*typeDecl
+	declared map[string]*typeDecl
+}
+
+// match returns the name to give a struct whose name is made up, which
+// would be name: that of a type with the same fields if one has been
+// declared and not claimed by the protocol of build, and otherwise a
+// name that is not yet declared.
+func (ts *types) match(name string, fields []goField, build int, claimed map[string]bool) string {
+	if d := ts.declared[name]; d != nil && !claimed[name] && sameFields(d.fields, fields) {
+		return name
+	}
+	for _, d := range ts.decls {
+		if d.derived && d.build != build && !claimed[d.name] && sameFields(d.fields, fields) {
+			return d.name
+		}
+	}
+	if ts.declared[name] != nil || claimed[name] {
+		name = fmt.Sprintf("%s_%d", name, build)
+	}
+	return name
+}
+
+// intTypes are the Go types of integers, narrowest first.
+var intTypes = []string{"int8", "int16", "int32", "int64"}
+
+func intRank(typ string) int {
+	for i, t := range intTypes {
+		if t == typ {
+			return i
+		}
+	}
+	return -1
+}
+
+// widen returns the wider of the Go types a and b, which may be
+// integers or pointers to or slices of integers.
+func widen(a, b string) (string, bool) {
+	for _, prefix := range []string{"", "*", "[]"} {
+		if !strings.HasPrefix(a, prefix) || !strings.HasPrefix(b, prefix) {
+			continue
+		}
+		ra, rb := intRank(a[len(prefix):]), intRank(b[len(prefix):])
+		if ra >= 0 && rb >= 0 {
+			if rb > ra {
+				ra = rb
+			}
+			return prefix + intTypes[ra], true
+		}
+	}
+	return "", false
+}
+
+// convert returns the expression expr, of the Go type from, converted
+// to the type to, which widen returned for it.
+func convert(expr, from, to string) string {
+	switch {
+	case from == to:
+		return expr
+	case strings.HasPrefix(from, "*"):
+		return fmt.Sprintf("convertPtr[%s, %s](%s)", from[1:], to[1:], expr)
+	case strings.HasPrefix(from, "[]"):
+		return fmt.Sprintf("convertSlice[%s, %s](%s)", from[2:], to[2:], expr)
+	}
+	return fmt.Sprintf("%s(%s)", to, expr)
+}
+
+// add declares d, merging it into the type of the same name if there
+// is one.
+func (ts *types) add(d *typeDecl) error {
+	other := ts.declared[d.name]
+	if other == nil {
+		ts.declared[d.name] = d
+		ts.decls = append(ts.decls, d)
+		return nil
+	}
+	if sameFields(d.fields, other.fields) && d.event == other.event {
+		return nil
+	}
+	if d.event != other.event {
+		return fmt.Errorf("protocols %d and %d disagree on whether %s is an event", other.build, d.build, d.name)
+	}
+	for _, f := range d.fields {
+		of := other.field(f.name)
+		if of == nil {
+			other.fields = append(other.fields, f)
+			continue
+		}
+		typ, ok := widen(of.typ, f.typ)
+		if of.typ != f.typ && !ok {
+			return fmt.Errorf("protocols %d and %d declare %s.%s as %s and %s; name their typeinfos differently",
+				other.build, d.build, d.name, f.name, of.typ, f.typ)
+		}
+		if ok {
+			of.typ = typ
+		}
+	}
+	return nil
+}
+
+// genTypes emits the Go types shared by all protocols, and the header
+// decoder, which as the header's layout is the same for all builds
+// uses the newest protocol's.
+func genTypes(decls []*typeDecl, newest string) ([]byte, error) {
+	g := &gen{}
+	g.Print("type EventMeta struct {")
+	g.Print("GameLoop int")
+	g.Print("UserId int")
+	g.Print("}")
+	g.Print("type Event interface {")
+	g.Print("Meta() *EventMeta")
+	g.Print("}")
+	g.Print("func (e *EventMeta) Meta() *EventMeta {")
+	g.Print("return e")
+	g.Print("}")
+
+	for _, decl := range decls {
+		g.Print("")
+		for _, c := range decl.comments {
+			g.Print("// %s", c)
+		}
+		g.Print("type %s struct {", decl.name)
+		if decl.event {
+			g.Print("EventMeta")
+		}
+		for _, f := range decl.fields {
+			if f.build != decl.build {
+				g.Print("%s %s // %d in %d", f.name, f.typ, f.typeinfo, f.build)
+			} else {
+				g.Print("%s %s // %d", f.name, f.typ, f.typeinfo)
+			}
+		}
+		g.Print("}")
+	}
+
+	g.Print("")
+	g.Print("// decodeVersionedHeader decodes a header, whose layout is the same")
+	g.Print("// for all builds, with the newest protocol's decoder.")
+	g.Print("func decodeVersionedHeader(d *versionedDecoder, tok blizzval.Token) *Header {")
+	g.Print("return %s{}.decodeVersionedHeader(d, tok)", newest)
+	g.Print("}")
+	return source(g, "blizzval")
+}
+
+// assign returns the assignment of the value decoded by expr to the
+// field f of the struct type name, converting it to the field's type.
+func (g *gen) assign(name string, f field, expr string) string {
+	fieldName := fieldToGo(f.Name)
+	typ := g.types.declared[name].field(fieldName).typ
+	return fmt.Sprintf("%s = %s", fieldName, convert(expr, g.typeinfos[f.Type].typ, typ))
+}
+
+// genDecoders emits the decoders of a group and registers its protocol.
+func (g *gen) genDecoders(gr *group) ([]byte, error) {
+	l := gr.layout
+	var buildList []string
+	for _, build := range gr.builds {
+		buildList = append(buildList, strconv.Itoa(build))
+	}
+	if len(buildList) == 1 {
+		g.Print("// %s decodes the replays of base build %s.", g.recv, buildList[0])
+	} else {
+		g.Print("// %s decodes the replays of base builds %s.", g.recv, strings.Join(buildList, ", "))
+	}
+	g.Print("type %s struct{}", g.recv)
+
+	imports := []string{"fmt", "blizzval"}
+	for _, t := range g.typeinfos {
+		if t.Kind == "blob" {
+			// The bit-packed blob decoders use io.ReadFull.
+			imports = append(imports, "io")
+			break
+		}
+	}
+	for _, t := range g.typeinfos {
+		switch t.Kind {
+		case "null", "int", "bool":
+			continue
+		}
+		if t.isEmptyStruct() {
+			continue
+		}
+		g.Print("")
+		g.Print("// typeinfo %d (%s)", t.id, t.Kind)
+		for _, name := range t.goNames() {
+			g.genDecode(t, name)
+		}
+		for _, name := range t.goNames() {
+			g.Print("")
+			g.genVersioned(t, name)
+		}
+	}
+
+	// Tracker events are in the versioned encoding, read below.
+	for _, events := range []struct {
+		name  string
+		types map[int]eventType
+	}{
+		{"Game", l.GameEventTypes},
+		{"Message", l.MessageEventTypes},
+	} {
+		g.Print("func (p %s) read%sEvent(r *bitReader, typ int) Event {", g.recv, events.name)
+		g.Print("switch typ {")
+		for _, id := range sortedKeys(events.types) {
+			et := events.types[id]
+			g.Print("case %d: return %s", id, g.typeinfos[et.Type].decodeCode(simpleName(et.Name)))
+		}
+		g.Print("default:")
+		g.Print("panic(fmt.Errorf(\"unknown event type %%d\", typ))")
+		g.Print("}")
+		g.Print("}")
+	}
+
+	g.Print("func (p %s) readVersionedTrackerEvent(d *versionedDecoder, tok blizzval.Token, typ int) Event {", g.recv)
+	g.Print("switch typ {")
+	for _, id := range sortedKeys(l.TrackerEventTypes) {
+		g.Print("case %d: return p.decodeVersioned%s(d, tok)", id, simpleName(l.TrackerEventTypes[id].Name))
+	}
+	g.Print("default:")
+	g.Print("panic(fmt.Errorf(\"unknown event type %%d\", typ))")
+	g.Print("}")
+	g.Print("}")
+
+	userIdBits, err := g.userIdBits(l.ReplayUserIdTypeid)
+	if err != nil {
+		return nil, fmt.Errorf("protocol %d: %w", gr.builds[0], err)
+	}
+	g.Print("")
+	g.Print("var protocol%d = &protocol{", gr.builds[0])
+	g.Print("userIdBits: %d,", userIdBits)
+	g.Print("gameEventIdBits: %d,", g.eventIdBits(l.GameEventIdTypeid))
+	g.Print("messageEventIdBits: %d,", g.eventIdBits(l.MessageEventIdTypeid))
+	g.Print("decodeVersionedGameLoopDelta: %s{}.decodeVersioned%s,", g.recv, g.typeinfos[l.Svaruint32Typeid].name)
+	g.Print("decodeDetails: %s{}.decodeVersionedDetails,", g.recv)
+	g.Print("decodeInitData: %s{}.decodeInitData,", g.recv)
+	g.Print("readGameEvent: %s{}.readGameEvent,", g.recv)
+	g.Print("readMessageEvent: %s{}.readMessageEvent,", g.recv)
+	g.Print("readTrackerEvent: %s{}.readVersionedTrackerEvent,", g.recv)
+	g.Print("}")
+	g.Print("")
+	g.Print("func init() {")
+	g.Print("registerProtocol(protocol%d, %s)", gr.builds[0], strings.Join(buildList, ", "))
+	g.Print("}")
+	return source(g, imports...)
+}
+
+// source formats the code in g as a file of package replay importing
+// the given packages, which are named by their last elements.
+func source(g *gen, imports ...string) ([]byte, error) {
+	paths := map[string]string{"blizzval": "blizzard/blizzval"}
+	var std, local []string
+	for _, imp := range imports {
+		if path, ok := paths[imp]; ok {
+			local = append(local, strconv.Quote(path))
+		} else {
+			std = append(std, strconv.Quote(imp))
+		}
+	}
+	sort.Strings(std)
+	if len(std) > 0 {
+		std = append(std, "")
+	}
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by blizzard/replay/gen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package replay\n\n")
+	fmt.Fprintf(&out, "import (\n%s\n)\n\n", strings.Join(append(std, local...), "\n"))
+	out.Write(g.Bytes())
+	return format.Source(out.Bytes())
+}
+
+// generate returns the generated files for groups, by name.
+func generate(groups []*group) (map[string][]byte, error) {
+	// The newest protocols come first, so that their typeinfo ids are
+	// the ones given in the comments of the shared types.
+	var ordered []*group
+	for i := len(groups) - 1; i >= 0; i-- {
+		ordered = append(ordered, groups[i])
+	}
+	newest := ordered[0]
+
+	ts := &types{declared: map[string]*typeDecl{}}
+	var gens []*gen
+	for _, gr := range ordered {
+		g := &gen{
+			typeinfos: gr.Typeinfos,
+			group:     gr,
+			recv:      fmt.Sprintf("decoders%d", gr.builds[0]),
+			types:     ts,
+			claimed:   map[string]bool{},
+		}
+		for id, t := range g.typeinfos {
+			t.id = id
+		}
+		g.nameTypes(gr.layout, gr.names)
+		for _, decl := range g.typeDecls() {
+			if err := ts.add(decl); err != nil {
+				return nil, err
+			}
+		}
+		gens = append(gens, g)
+	}
+
+	files := map[string][]byte{}
+	for _, g := range gens {
+		src, err := g.genDecoders(g.group)
+		if err != nil {
+			return nil, err
+		}
+		files[fmt.Sprintf("typeinfo%d.go", g.group.builds[0])] = src
+	}
+	src, err := genTypes(ts.decls, fmt.Sprintf("decoders%d", newest.builds[0]))
+	if err != nil {
+		return nil, err
+	}
+	files["typeinfo.go"] = src
+	return files, nil
+}
+
+// generatedFiles lists the files of dir that the generator writes.
+func generatedFiles(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "typeinfo*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, path := range paths {
+		name := filepath.Base(path)
+		build := strings.TrimSuffix(strings.TrimPrefix(name, "typeinfo"), ".go")
+		if _, err := strconv.Atoi(build); err == nil || build == "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func main() {
+	dir := flag.String("dir", ".", "output directory")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: gen [-dir dir] protocols\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	groups, err := read(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	files, err := generate(groups)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Remove the decoders of builds whose definitions are gone.
+	old, err := generatedFiles(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, name := range old {
+		if files[name] == nil {
+			if err := os.Remove(filepath.Join(*dir, name)); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(*dir, name), src, 0666); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestGenerated checks that the checked-in typeinfo files are up to
+// date with the generator and the protocol definitions.
+func TestGenerated(t *testing.T) {
+	groups, err := read("../protocols")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := generate(groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, src := range files {
+		exp, err := os.ReadFile(filepath.Join("..", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(src, exp) {
+			t.Errorf("%s is out of date; run go generate", name)
+		}
+	}
+	names, err := generatedFiles("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		if files[name] == nil {
+			t.Errorf("%s is stale; run go generate", name)
+		}
+	}
+}
+
+func TestReadLayouts(t *testing.T) {
+	data, err := os.ReadFile("../protocols/34835.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	write := func(name, data string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A build with the same layout shares the decoders and the names.
+	write("34835.json", string(data))
+	write("35000.json", string(data[:bytes.Index(data, []byte(",\n\t\"names\""))])+"\n}\n")
+	groups, err := read(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || !reflect.DeepEqual(groups[0].builds, []int{34835, 35000}) || groups[0].names[26] != "Player" {
+		t.Errorf("got %d groups, the first of builds %v, names %v", len(groups), groups[0].builds, groups[0].names)
+	}
+
+	write("35000.json", strings.Replace(string(data), `"26": "Player"`, `"26": "Person"`, 1))
+	if _, err := read(dir); err == nil {
+		t.Errorf("read conflicting names without error")
+	}
+
+	// A build with another layout, here wider user ids, has its own
+	// decoders.
+	write("35000.json", strings.Replace(string(data), `["_int", [[0, 5]]]`, `["_int", [[0, 6]]]`, 1))
+	groups, err = read(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 || groups[0].builds[0] != 34835 || groups[1].builds[0] != 35000 {
+		t.Fatalf("got %d groups, expected 34835 and 35000", len(groups))
+	}
+	files, err := generate(groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"typeinfo.go", "typeinfo34835.go", "typeinfo35000.go"} {
+		if files[name] == nil {
+			t.Errorf("no %s generated", name)
+		}
+	}
+	for name, exp := range map[string]string{
+		"typeinfo34835.go": `userIdBits:\s+5,`,
+		"typeinfo35000.go": `userIdBits:\s+6,`,
+	} {
+		if !regexp.MustCompile(exp).Match(files[name]) {
+			t.Errorf("%s lacks %s", name, exp)
+		}
+	}
+	if src := string(files["typeinfo35000.go"]); !strings.Contains(src, "registerProtocol(protocol35000, 35000)") {
+		t.Errorf("typeinfo35000.go does not register its protocol")
+	}
+}
+
+// TestMergeTypes checks that the Go types of protocols with differing
+// structs have the fields of all of them, at the widest integer types,
+// and that the generated code compiles.
+func TestMergeTypes(t *testing.T) {
+	data, err := os.ReadFile("../protocols/34835.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	write := func(name, data string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("34835.json", string(data))
+	// Build 35000 widens the recipients of chat messages and adds a
+	// field to players.
+	newer := strings.Replace(string(data), `[["m_recipient", 12, 0], ["m_string", 30, 1]]`, `[["m_recipient", 4, 0], ["m_string", 30, 1]]`, 1)
+	newer = strings.Replace(newer, `["m_hero", 9, 10]]]]`, `["m_hero", 9, 10], ["m_extra", 2, 11]]]]`, 1)
+	write("35000.json", newer)
+
+	groups, err := read(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := generate(groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := string(files["typeinfo.go"])
+	for _, exp := range []string{"Recipient int16", "Extra "} {
+		if !strings.Contains(types, exp) {
+			t.Errorf("typeinfo.go lacks %q", exp)
+		}
+	}
+	if src := string(files["typeinfo34835.go"]); !strings.Contains(src, "out.Recipient = int16(int8(readBits(r, 3)))") {
+		t.Errorf("typeinfo34835.go does not widen the recipient")
+	}
+
+	if testing.Short() {
+		return
+	}
+	// Build the package with the generated files in place of its own.
+	pkg := t.TempDir()
+	paths, err := filepath.Glob("../*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range paths {
+		name := filepath.Base(path)
+		if strings.HasPrefix(name, "typeinfo") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[name] = src
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(pkg, name), src, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cmd := exec.Command("go", "build", "-o", os.DevNull, ".")
+	cmd.Dir = pkg
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("generated code does not build: %s\n%s", err, out)
+	}
+
+	// Types that cannot be merged need other names.
+	write("35000.json", strings.Replace(string(data), `["m_name", 9, 0], ["m_toon", 22, 1]`, `["m_name", 2, 0], ["m_toon", 22, 1]`, 1))
+	if groups, err = read(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := generate(groups); err == nil || !strings.Contains(err.Error(), "Player.Name") {
+		t.Errorf("got %v, expected an error about Player.Name", err)
+	}
+}
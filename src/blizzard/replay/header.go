@@ -0,0 +1,27 @@
+package replay
+
+import (
+	"bytes"
+	"errors"
+	"time"
+)
+
+// ReadHeader decodes a replay header from the user data of a replay's
+// archive.
+func ReadHeader(userData []byte) (h *Header, err error) {
+	if len(userData) == 0 {
+		return nil, errors.New("replay: archive has no header")
+	}
+	defer catchError(&err)
+	d := newVersionedDecoder(bytes.NewReader(userData))
+	return decodeVersionedHeader(d, d.token()), nil
+}
+
+// GameLoopsPerSecond is the number of game loops in a second of game
+// time.
+const GameLoopsPerSecond = 16
+
+// Duration returns the length of the game, in game time.
+func (h *Header) Duration() time.Duration {
+	return time.Duration(h.ElapsedGameLoops) * time.Second / GameLoopsPerSecond
+}
@@ -0,0 +1,100 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"blizzard/blizzval"
+	"blizzard/mpq"
+)
+
+//go:generate go run blizzard/replay/gen protocols
+
+// ErrUnsupportedBuild is returned for replays of a game build whose
+// protocol this package has no decoders for.
+var ErrUnsupportedBuild = errors.New("replay: unsupported game build")
+
+// protocol holds the generated decoders for the replay files of one
+// protocol layout, which may be shared by several game builds.  The
+// header's layout is the same for all builds, so it is not here.
+type protocol struct {
+	userIdBits         int
+	gameEventIdBits    int
+	messageEventIdBits int
+
+	decodeVersionedGameLoopDelta func(d *versionedDecoder, tok blizzval.Token) int32
+	decodeDetails                func(d *versionedDecoder, tok blizzval.Token) *Details
+	decodeInitData               func(r *bitReader) *InitData
+	readGameEvent                func(r *bitReader, typ int) Event
+	readMessageEvent             func(r *bitReader, typ int) Event
+	readTrackerEvent             func(d *versionedDecoder, tok blizzval.Token, typ int) Event
+}
+
+// protocols maps base builds to their protocols.  It is filled in by
+// the generated code, which so far covers only base build 34835;
+// another build needs its protocol added to protocols as JSON.
+var protocols = map[int]*protocol{}
+
+func registerProtocol(p *protocol, baseBuilds ...int) {
+	for _, build := range baseBuilds {
+		protocols[build] = p
+	}
+}
+
+// lookupProtocol returns the protocol of replays of baseBuild.
+func lookupProtocol(baseBuild int) (*protocol, error) {
+	p := protocols[baseBuild]
+	if p == nil {
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedBuild, baseBuild)
+	}
+	return p, nil
+}
+
+// archiveProtocol returns the protocol of the replay in a, as given by
+// the base build of its header.
+func archiveProtocol(a *mpq.Reader) (*protocol, error) {
+	h, err := ReadHeader(a.UserData())
+	if err != nil {
+		return nil, err
+	}
+	return lookupProtocol(int(h.Version.BaseBuild))
+}
+
+// SupportedBuilds returns the base builds whose replays can be decoded,
+// in increasing order.
+func SupportedBuilds() []int {
+	builds := make([]int, 0, len(protocols))
+	for build := range protocols {
+		builds = append(builds, build)
+	}
+	sort.Ints(builds)
+	return builds
+}
+
+// integer is the types of integers that protocols may disagree on the
+// width of.
+type integer interface {
+	~int8 | ~int16 | ~int32 | ~int64
+}
+
+// convertPtr and convertSlice widen integers decoded by one protocol to
+// the Go types shared with protocols that have wider ones.
+func convertPtr[T, U integer](p *T) *U {
+	if p == nil {
+		return nil
+	}
+	u := U(*p)
+	return &u
+}
+
+func convertSlice[T, U integer](s []T) []U {
+	if s == nil {
+		return nil
+	}
+	out := make([]U, len(s))
+	for i, v := range s {
+		out[i] = U(v)
+	}
+	return out
+}
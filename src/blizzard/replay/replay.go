@@ -6,60 +6,130 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
 
-	"blizzard/blizzval"
 	"blizzard/mpq"
 )
 
-type Player struct {
-	Raw  blizzval.Value
-	Name string "0"
-	// bnet region "1"
-	// starcraft race "2"
-	// color "3"
-	Team     int64 "5"
-	Handicap int64 "6"
-	// unknown "7"
-	IsWinner int64 "8"
-	// unknown "9"
-	Character string "10"
+// Replay is an opened replay file.
+type Replay struct {
+	// Archive is the replay's archive, for access to files this
+	// package does not decode.
+	Archive *mpq.Reader
+
+	// Header gives the game version and length.  It is decoded from
+	// the archive's user data before any file of the archive is read.
+	Header *Header
+
+	// Details describes the players and map of the game.
+	Details *Details
+
+	proto *protocol
+}
+
+// Open opens the replay at path.  It returns an error wrapping
+// ErrUnsupportedBuild if the replay's game build is not supported.
+func Open(path string) (*Replay, error) {
+	a, err := mpq.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := newReplay(a)
+	if err != nil {
+		a.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Decode reads a replay from ra, which has the given size.
+func Decode(ra io.ReaderAt, size int64) (*Replay, error) {
+	a, err := mpq.NewReader(ra, size)
+	if err != nil {
+		return nil, err
+	}
+	return newReplay(a)
+}
+
+func newReplay(a *mpq.Reader) (*Replay, error) {
+	r := &Replay{Archive: a}
+	var err error
+	if r.Header, err = ReadHeader(a.UserData()); err != nil {
+		return nil, err
+	}
+	if r.proto, err = lookupProtocol(int(r.Header.Version.BaseBuild)); err != nil {
+		return nil, err
+	}
+	if r.Details, err = r.decodeDetails(); err != nil {
+		return nil, fmt.Errorf("replay: replay.details: %w", err)
+	}
+	return r, nil
+}
+
+// Close closes the replay's archive, if it was opened by Open.
+func (r *Replay) Close() error {
+	return r.Archive.Close()
 }
 
-type Details struct {
-	Raw     blizzval.Value
-	Players []*Player "0"
-	Map     string    "1"
-	// 2 unknown empty string
-	// 3 preview image(?)
-	// 4 always 1 ?
-	TimeStamp int64 "5"
-	UTCOffset int64 "6"
-	// And a bunch more mystery fields...
+// decodeDetails decodes replay.details, which like the header is in
+// the versioned encoding.
+func (r *Replay) decodeDetails() (details *Details, err error) {
+	f, err := r.Archive.OpenFile("replay.details")
+	if err != nil {
+		return nil, err
+	}
+	defer catchError(&err)
+	d := newVersionedDecoder(bufio.NewReader(f))
+	return r.proto.decodeDetails(d, d.token()), nil
 }
 
-func readReplayDetails(r *mpq.Reader) {
-	fr := r.OpenFile("replay.details")
-	e := blizzval.Read(bufio.NewReader(fr))
+// InitData decodes replay.initData, which describes the lobby the
+// game was started from: the users' initial data, the game options and
+// the slots.
+func (r *Replay) InitData() (*InitData, error) {
+	initData, err := r.decodeInitData()
+	if err != nil {
+		return nil, fmt.Errorf("replay: replay.initData: %w", err)
+	}
+	return initData, nil
+}
 
-	// printTrackerEvent(e, 0)
-	// fmt.Printf("\n")
+func (r *Replay) decodeInitData() (initData *InitData, err error) {
+	f, err := r.Archive.OpenFile("replay.initData")
+	if err != nil {
+		return nil, err
+	}
+	defer catchError(&err)
+	return r.proto.decodeInitData(newBitReader(bufio.NewReader(f))), nil
+}
 
-	d := readDetails(e)
-	log.Printf("%#v", d)
-	// for _, p := range d.Players {
-	// 	log.Printf("%#v", p)
-	// }
+// Attributes decodes replay.attributes.events, which holds settings of
+// the lobby such as each player's hero.
+func (r *Replay) Attributes() (*Attributes, error) {
+	f, err := r.Archive.OpenFile("replay.attributes.events")
+	if err != nil {
+		return nil, fmt.Errorf("replay: replay.attributes.events: %w", err)
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("replay: replay.attributes.events: %w", err)
+	}
+	return ReadAttributes(data)
+}
+
+// GameEvents opens the replay's game event stream.
+func (r *Replay) GameEvents() (*GameEventReader, error) {
+	return newGameEventReader(r.Archive, r.proto)
 }
 
-type lr struct {
-	r io.Reader
+// MessageEvents opens the replay's message event stream, which holds
+// chat messages and pings.
+func (r *Replay) MessageEvents() (*MessageEventReader, error) {
+	return newMessageEventReader(r.Archive, r.proto)
 }
 
-func (r *lr) Read(buf []byte) (int, error) {
-	n, err := r.r.Read(buf)
-	log.Printf("read %d %x", n, buf[:n])
-	return n, err
+// TrackerEvents opens the replay's tracker event stream.
+func (r *Replay) TrackerEvents() (*TrackerEventReader, error) {
+	return newTrackerEventReader(r.Archive, r.proto)
 }
 
 type TrackerEventType int
@@ -76,44 +146,47 @@ const (
 	EventPlayerSetup   TrackerEventType = 9
 )
 
-type TrackerEvent struct {
-	Frame int64
-	Type  TrackerEventType
-	Val   blizzval.Value
+// TrackerEventReader reads the events of replay.tracker.events,
+// which record unit and player statistics.
+type TrackerEventReader struct {
+	d        *versionedDecoder
+	proto    *protocol
+	gameLoop int
 }
 
-func readReplayTrackerEvents(mpqr *mpq.Reader) {
-	r := mpqr.OpenFile("replay.tracker.events")
+func NewTrackerEventReader(mpqr *mpq.Reader) (*TrackerEventReader, error) {
+	p, err := archiveProtocol(mpqr)
+	if err != nil {
+		return nil, err
+	}
+	return newTrackerEventReader(mpqr, p)
+}
 
-	for i := 0; i < 10000; i++ {
-		te := &TrackerEvent{}
-		var buf [3]byte
-		_, err := r.Read(buf[:3])
-		if err != nil {
-			if err == io.EOF {
-				return
-			}
-			panic(err)
-		}
-		if string(buf[:3]) != "\x03\x00\x09" &&
-			string(buf[:3]) != "\x03\x02\x09" {
-			panic(fmt.Errorf("unexpected event head %x", buf[:3]))
-		}
+func newTrackerEventReader(mpqr *mpq.Reader, p *protocol) (*TrackerEventReader, error) {
+	fr, err := mpqr.OpenFile("replay.tracker.events")
+	if err != nil {
+		return nil, err
+	}
+	return &TrackerEventReader{d: newVersionedDecoder(bufio.NewReader(fr)), proto: p}, nil
+}
 
-		te.Frame = blizzval.ReadVarInt(r)
+// Next returns the next event, such as a *UnitBornEvent.  It returns
+// io.EOF at the end of the stream.
+func (r *TrackerEventReader) Next() (event Event, err error) {
+	// The first token is read directly, so that the end of the stream
+	// is io.EOF rather than io.ErrUnexpectedEOF.
+	tok, err := r.d.d.Token()
+	if err != nil {
+		return nil, err
+	}
+	defer catchError(&err)
 
-		_, err = r.Read(buf[:1])
-		if err != nil {
-			panic(err)
-		}
-		if string(buf[:1]) != "\x09" {
-			panic("unexpected event trailer")
-		}
+	r.gameLoop += int(r.proto.decodeVersionedGameLoopDelta(r.d, tok))
+	eventId := int(r.d.int(r.d.token()))
+	event = r.proto.readTrackerEvent(r.d, r.d.token(), eventId)
+	event.Meta().GameLoop = r.gameLoop
 
-		te.Type = TrackerEventType(blizzval.ReadVarInt(r))
-		te.Val = blizzval.Read(r)
-		log.Printf("%d %+v", i, te)
-	}
+	return event, nil
 }
 
 func readBits(r *bitReader, n int) uint64 {
@@ -145,34 +218,98 @@ func decodeGameLoopDelta(r *bitReader) (int, error) {
 
 type GameEventReader struct {
 	r        *bitReader
+	proto    *protocol
 	gameLoop int
 }
 
-func NewGameEventReader(mpqr *mpq.Reader) *GameEventReader {
-	fr := mpqr.OpenFile("replay.game.events")
+func NewGameEventReader(mpqr *mpq.Reader) (*GameEventReader, error) {
+	p, err := archiveProtocol(mpqr)
+	if err != nil {
+		return nil, err
+	}
+	return newGameEventReader(mpqr, p)
+}
+
+func newGameEventReader(mpqr *mpq.Reader, p *protocol) (*GameEventReader, error) {
+	fr, err := mpqr.OpenFile("replay.game.events")
+	if err != nil {
+		return nil, err
+	}
 	r := newBitReader(bufio.NewReader(fr))
 
-	return &GameEventReader{r: r}
+	return &GameEventReader{r: r, proto: p}, nil
 }
 
-func (r *GameEventReader) Read() Event {
-	delta, err := decodeGameLoopDelta(r.r)
+// Next returns the next event.  It returns io.EOF at the end of the
+// stream.
+func (r *GameEventReader) Next() (Event, error) {
+	return nextEvent(r.r, &r.gameLoop, r.proto.userIdBits, r.proto.gameEventIdBits, r.proto.readGameEvent)
+}
+
+// nextEvent reads the next event of a bit-packed event stream: the
+// game loop delta, a user id of userIdBits bits, an event id of idBits
+// bits and then the event, decoded by read.
+func nextEvent(r *bitReader, gameLoop *int, userIdBits, idBits int, read func(*bitReader, int) Event) (event Event, err error) {
+	// The generated decoders panic on errors.
+	defer catchError(&err)
+
+	delta, err := decodeGameLoopDelta(r)
 	if err != nil {
-		if err == io.EOF {
-			return nil
-		}
-		panic(err)
+		return nil, err
 	}
-	r.gameLoop += delta
-	userId := int(readBits(r.r, 5))
+	*gameLoop += delta
+	userId := int(readBits(r, userIdBits))
 
-	eventId := int(readBits(r.r, 7))
-	event := readGameEvent(r.r, eventId)
-	r.r.SyncToByte()
+	eventId := int(readBits(r, idBits))
+	event = read(r, eventId)
+	r.SyncToByte()
 
 	meta := event.Meta()
-	meta.GameLoop = r.gameLoop
+	meta.GameLoop = *gameLoop
 	meta.UserId = userId
 
+	return event, nil
+}
+
+// Read returns the next event, or nil at the end of the stream.  It
+// panics on errors.
+func (r *GameEventReader) Read() Event {
+	event, err := r.Next()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		panic(err)
+	}
 	return event
 }
+
+// MessageEventReader reads the events of replay.message.events, such
+// as *ChatMessage and *PingMessage.
+type MessageEventReader struct {
+	r        *bitReader
+	proto    *protocol
+	gameLoop int
+}
+
+func NewMessageEventReader(mpqr *mpq.Reader) (*MessageEventReader, error) {
+	p, err := archiveProtocol(mpqr)
+	if err != nil {
+		return nil, err
+	}
+	return newMessageEventReader(mpqr, p)
+}
+
+func newMessageEventReader(mpqr *mpq.Reader, p *protocol) (*MessageEventReader, error) {
+	fr, err := mpqr.OpenFile("replay.message.events")
+	if err != nil {
+		return nil, err
+	}
+	return &MessageEventReader{r: newBitReader(bufio.NewReader(fr)), proto: p}, nil
+}
+
+// Next returns the next event.  It returns io.EOF at the end of the
+// stream.
+func (r *MessageEventReader) Next() (Event, error) {
+	return nextEvent(r.r, &r.gameLoop, r.proto.userIdBits, r.proto.messageEventIdBits, r.proto.readMessageEvent)
+}
@@ -0,0 +1,360 @@
+package replay
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"blizzard/blizzval"
+	"blizzard/mpq/mpqtest"
+)
+
+func encode(t *testing.T, vals ...blizzval.Value) []byte {
+	var buf bytes.Buffer
+	for _, v := range vals {
+		if err := blizzval.Encode(&buf, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+var testDetails = map[int]blizzval.Value{
+	0: []blizzval.Value{
+		map[int]blizzval.Value{
+			0: "alice",
+			// The toon's program id is a fourcc, encoded as a u32.
+			1: map[int]blizzval.Value{0: uint8(1), 1: uint32(0x6f726548), 2: uint32(1), 3: "alice", 4: int64(1234)},
+			5: int64(0), 6: int64(100), 8: int64(1), 10: "Zeratul",
+		},
+		map[int]blizzval.Value{0: "bob", 5: int64(1), 6: int64(100), 8: int64(2), 10: "Raynor"},
+	},
+	1: "Cursed Hollow",
+	5: int64(130000000000000000),
+	6: int64(-36000000000),
+}
+
+var testHeader = map[int]blizzval.Value{
+	0: "Heroes of the Storm replay\x1b11",
+	1: map[int]blizzval.Value{
+		0: int64(1), 1: int64(0), 2: int64(12), 3: int64(0),
+		4: int64(34835), 5: int64(34835),
+	},
+	2: int64(2),
+	3: int64(16 * 600),
+	4: uint8(1),
+	5: map[int]blizzval.Value{0: nil, 1: strings.Repeat("k", 16)},
+	6: int64(34835),
+	// An unknown field, which is skipped.
+	7: []blizzval.Value{map[int]blizzval.Value{0: "x"}},
+}
+
+// trackerEvent encodes a tracker event with its framing.
+func trackerEvent(t *testing.T, delta int64, typ int64, v blizzval.Value) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("\x03\x00\x09")
+	blizzval.WriteVarInt(&buf, delta)
+	buf.WriteByte(0x09)
+	blizzval.WriteVarInt(&buf, typ)
+	buf.Write(encode(t, v))
+	return buf.Bytes()
+}
+
+func decodeTestReplay(t *testing.T, files ...mpqtest.File) *Replay {
+	archive := mpqtest.Build(encode(t, testHeader), files...)
+	r, err := Decode(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestDecode(t *testing.T) {
+	r := decodeTestReplay(t, mpqtest.File{Name: "replay.details", Data: encode(t, testDetails)})
+	defer r.Close()
+	d := r.Details
+	if d.Title != "Cursed Hollow" || d.PlayerList == nil || len(*d.PlayerList) != 2 || d.TimeLocalOffset != -36000000000 {
+		t.Fatalf("bad details %+v", d)
+	}
+	if toon := (*d.PlayerList)[0].Toon; toon == nil || toon.ProgramId.String() != "Hero" || toon.Id != 1234 {
+		t.Errorf("bad toon %+v", toon)
+	}
+	if p := (*d.PlayerList)[1]; p.Name != "bob" || p.TeamId != 1 || p.Result != 2 || p.Hero != "Raynor" {
+		t.Errorf("bad player %+v", p)
+	}
+
+	archive := mpqtest.Build(encode(t, testHeader))
+	if _, err := Decode(bytes.NewReader(archive), int64(len(archive))); err == nil {
+		t.Errorf("decoded replay without details")
+	}
+}
+
+func TestUnsupportedBuild(t *testing.T) {
+	header := map[int]blizzval.Value{}
+	for k, v := range testHeader {
+		header[k] = v
+	}
+	header[1] = map[int]blizzval.Value{4: int64(12345), 5: int64(12345)}
+	archive := mpqtest.Build(encode(t, header), mpqtest.File{Name: "replay.details", Data: encode(t, testDetails)})
+	_, err := Decode(bytes.NewReader(archive), int64(len(archive)))
+	if !errors.Is(err, ErrUnsupportedBuild) {
+		t.Errorf("got %v, expected ErrUnsupportedBuild", err)
+	}
+}
+
+func TestReadHeader(t *testing.T) {
+	h, err := ReadHeader(encode(t, testHeader))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Signature != "Heroes of the Storm replay\x1b11" || h.Version.BaseBuild != 34835 ||
+		!h.UseScaledTime || h.DataBuildNum != 34835 || h.NgdpRootKey.DataDeprecated != nil {
+		t.Errorf("bad header %+v %+v", h, h.Version)
+	}
+	if h.Duration() != 10*time.Minute {
+		t.Errorf("duration %s, expected 10m", h.Duration())
+	}
+
+	for _, in := range [][]byte{
+		nil,
+		encode(t, int64(1)),
+		encode(t, map[int]blizzval.Value{1: map[int]blizzval.Value{0: "x"}}),
+		encode(t, testHeader)[:20],
+	} {
+		if _, err := ReadHeader(in); err == nil {
+			t.Errorf("%q: decoded without error", in)
+		}
+	}
+}
+
+func TestTrackerEvents(t *testing.T) {
+	var events []byte
+	events = append(events, trackerEvent(t, 0, 9, map[int]blizzval.Value{0: int64(1)})...)
+	events = append(events, trackerEvent(t, 20, 1, map[int]blizzval.Value{0: int64(2), 2: "Marine"})...)
+	events = append(events, trackerEvent(t, 5, 0, map[int]blizzval.Value{0: int64(3)})...)
+	r := decodeTestReplay(t,
+		mpqtest.File{Name: "replay.details", Data: encode(t, testDetails)},
+		mpqtest.File{Name: "replay.tracker.events", Data: events},
+	)
+
+	tr, err := r.TrackerEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := tr.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, ok := e.(*PlayerSetupEvent); !ok || e.GameLoop != 0 || e.PlayerId != 1 {
+		t.Errorf("got %#v, expected PlayerSetupEvent", e)
+	}
+	e, err = tr.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, ok := e.(*UnitBornEvent); !ok || e.GameLoop != 20 || e.UnitTagIndex != 2 || e.UnitTypeName != "Marine" {
+		t.Errorf("got %#v, expected UnitBornEvent", e)
+	}
+	e, err = tr.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, ok := e.(*PlayerStatsEvent); !ok || e.GameLoop != 25 || e.PlayerId != 3 {
+		t.Errorf("got %#v, expected PlayerStatsEvent", e)
+	}
+	if _, err := tr.Next(); err != io.EOF {
+		t.Errorf("got %v at end, expected io.EOF", err)
+	}
+
+	for _, test := range []struct {
+		in  []byte
+		exp string
+	}{
+		{events[:len(events)-1], "unexpected EOF"},
+		{trackerEvent(t, 0, 99, map[int]blizzval.Value{}), "unknown event type 99"},
+		{trackerEvent(t, 0, 1, map[int]blizzval.Value{2: int64(1)}), "expected blob"},
+	} {
+		tr := &TrackerEventReader{d: newVersionedDecoder(bytes.NewReader(test.in)), proto: protocol34835}
+		var last error
+		for last == nil {
+			_, last = tr.Next()
+		}
+		if !strings.Contains(last.Error(), test.exp) {
+			t.Errorf("%q: got %v, expected %q", test.in, last, test.exp)
+		}
+	}
+}
+
+func TestGameEventsEmpty(t *testing.T) {
+	r := decodeTestReplay(t,
+		mpqtest.File{Name: "replay.details", Data: encode(t, testDetails)},
+		mpqtest.File{Name: "replay.game.events", Data: nil},
+	)
+	gr, err := r.GameEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gr.Next(); err != io.EOF {
+		t.Errorf("got %v, expected io.EOF", err)
+	}
+}
+
+func TestMessageEvents(t *testing.T) {
+	var w bitWriter
+	// A chat message at game loop 10, from user 3 to recipient 2.
+	w.WriteBits(0, 2)
+	w.WriteBits(10, 6)
+	w.WriteBits(3, 5)
+	w.WriteBits(0, 4)
+	w.WriteBits(2, 3)
+	w.WriteBits(2, 11)
+	w.WriteBytes("gg")
+	// A server ping 300 game loops later, from user 16.
+	w.SyncToByte()
+	w.WriteBits(1, 2)
+	w.WriteBits(300, 14)
+	w.WriteBits(16, 5)
+	w.WriteBits(3, 4)
+	r := decodeTestReplay(t,
+		mpqtest.File{Name: "replay.details", Data: encode(t, testDetails)},
+		mpqtest.File{Name: "replay.message.events", Data: w.buf},
+	)
+
+	mr, err := r.MessageEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := mr.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, ok := e.(*ChatMessage); !ok || e.GameLoop != 10 || e.UserId != 3 || e.Recipient != 2 || e.String != "gg" {
+		t.Errorf("got %#v, expected ChatMessage", e)
+	}
+	e, err = mr.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, ok := e.(*ServerPingMessage); !ok || e.GameLoop != 310 || e.UserId != 16 {
+		t.Errorf("got %#v, expected ServerPingMessage", e)
+	}
+	if _, err := mr.Next(); err != io.EOF {
+		t.Errorf("got %v at end, expected io.EOF", err)
+	}
+}
+
+func TestInitData(t *testing.T) {
+	var w bitWriter
+	zero := func(widths ...int) {
+		for _, n := range widths {
+			w.WriteBits(0, n)
+		}
+	}
+	blob := func(bits int, s string) {
+		w.WriteBits(uint64(len(s)), bits)
+		w.WriteBytes(s)
+	}
+
+	// One user's initial data.
+	w.WriteBits(1, 5)
+	blob(8, "alice")
+	w.WriteBits(1, 1)
+	blob(8, "CLAN")
+	zero(1, 1, 1, 1, 32, 1, 1, 1, 1, 1, 1, 32, 2)
+	blob(9, "")
+	blob(9, "")
+	blob(9, "")
+	blob(7, "1-Hero-1-1234")
+
+	// The game description, with one slot description.
+	zero(32)
+	blob(10, "")
+	zero(1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 64)
+	zero(3, 3, 5, 5, 5, 4, 6, 8, 8, 8, 8, 32)
+	blob(11, "Cursed Hollow.s2ma")
+	blob(8, "")
+	zero(32)
+	w.WriteBits(1, 5)
+	w.WriteBits(16, 6)
+	w.WriteBits(0xffff, 16)
+	w.WriteBits(4, 8)
+	w.WriteBits(0x5, 4)
+	zero(6)
+	w.WriteBits(2, 8)
+	w.WriteBits(0x3, 2)
+	zero(2)
+	w.WriteBits(70, 7)
+	w.WriteBits(0x20, 6)
+	w.WriteBits(1, 64)
+	zero(6, 7, 6, 1, 1, 1, 1)
+
+	// The lobby state, with one slot.
+	zero(3, 5, 5)
+	w.WriteBits(1, 5)
+	w.WriteBits(2, 8)
+	w.WriteBits(1, 1)
+	w.WriteBits(0, 4)
+	w.WriteBits(1, 4)
+	zero(1, 1)
+	w.WriteBits(3, 6)
+	zero(7)
+	w.WriteBits(100, 7)
+	zero(2, 32)
+	blob(9, "Zeratul")
+	blob(9, "")
+	blob(9, "")
+	zero(4, 1, 17)
+	blob(7, "1-Hero-1-1234")
+	zero(9, 1)
+	blob(9, "")
+	zero(32, 1, 1, 32, 6, 7)
+
+	r := decodeTestReplay(t,
+		mpqtest.File{Name: "replay.details", Data: encode(t, testDetails)},
+		mpqtest.File{Name: "replay.initData", Data: w.buf},
+	)
+	initData, err := r.InitData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lobby := initData.SyncLobbyState
+	if len(lobby.UserInitialData) != 1 {
+		t.Fatalf("got %d users, expected 1", len(lobby.UserInitialData))
+	}
+	if u := lobby.UserInitialData[0]; u.Name != "alice" || u.ClanTag == nil || *u.ClanTag != "CLAN" || u.ToonHandle != "1-Hero-1-1234" {
+		t.Errorf("bad user %+v", u)
+	}
+	if lobby.GameDescription.MapFileName != "Cursed Hollow.s2ma" || len(lobby.GameDescription.SlotDescriptions) != 1 {
+		t.Errorf("bad game description %+v", lobby.GameDescription)
+	}
+	desc := lobby.GameDescription.SlotDescriptions[0]
+	if desc.AllowedColors.Count() != 16 || desc.AllowedRaces.String() != "0101" || desc.AllowedDifficulty.Len != 0 {
+		t.Errorf("bad slot description %+v", desc)
+	}
+	if b := desc.AllowedAIBuilds; b.Len != 70 || b.Count() != 2 || !b.Bit(69) || !b.Bit(0) || b.Bit(70) {
+		t.Errorf("bad AI builds %s", b)
+	}
+	if len(lobby.LobbyState.Slots) != 1 {
+		t.Fatalf("got %d slots, expected 1", len(lobby.LobbyState.Slots))
+	}
+	if s := lobby.LobbyState.Slots[0]; s.Control != 2 || s.UserId == nil || *s.UserId != 0 || s.TeamId != 1 ||
+		s.Difficulty != 3 || s.Handicap != 100 || s.Hero != "Zeratul" {
+		t.Errorf("bad slot %+v", s)
+	}
+
+	r = decodeTestReplay(t, mpqtest.File{Name: "replay.details", Data: encode(t, testDetails)})
+	if _, err := r.InitData(); err == nil {
+		t.Errorf("decoded missing initData")
+	}
+	w.buf = w.buf[:len(w.buf)-4]
+	r = decodeTestReplay(t,
+		mpqtest.File{Name: "replay.details", Data: encode(t, testDetails)},
+		mpqtest.File{Name: "replay.initData", Data: w.buf},
+	)
+	if _, err := r.InitData(); err == nil {
+		t.Errorf("decoded truncated initData")
+	}
+}
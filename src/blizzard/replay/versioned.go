@@ -0,0 +1,145 @@
+package replay
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"runtime"
+
+	"blizzard/blizzval"
+)
+
+// versionedDecoder decodes values in the "versioned" encoding, which
+// tags each value with its type, as blizzval does.  Like the
+// bit-packed decoders, its methods panic on errors, which catchError
+// recovers at the API boundary.
+type versionedDecoder struct {
+	d *blizzval.Decoder
+}
+
+func newVersionedDecoder(r io.Reader) *versionedDecoder {
+	return &versionedDecoder{blizzval.NewDecoder(r)}
+}
+
+// catchError recovers a panic with an error, storing it in *err.
+// Runtime errors are bugs rather than bad input, so they keep
+// panicking.
+func catchError(err *error) {
+	if p := recover(); p != nil {
+		perr, ok := p.(error)
+		if _, isRuntime := p.(runtime.Error); !ok || isRuntime {
+			panic(p)
+		}
+		if perr == io.EOF {
+			perr = io.ErrUnexpectedEOF
+		}
+		*err = perr
+	}
+}
+
+// token returns the next token, which begins a value.
+func (d *versionedDecoder) token() blizzval.Token {
+	tok, err := d.d.Token()
+	if err != nil {
+		panic(err)
+	}
+	return tok
+}
+
+func mismatch(tok blizzval.Token, want string) error {
+	return fmt.Errorf("replay: got %s with tag %#x, expected %s", tok.Kind, tok.Tag, want)
+}
+
+func (d *versionedDecoder) int(tok blizzval.Token) int64 {
+	if tok.Kind != blizzval.KindInt {
+		panic(mismatch(tok, "integer"))
+	}
+	return tok.Int
+}
+
+func (d *versionedDecoder) bool(tok blizzval.Token) bool {
+	return d.int(tok) != 0
+}
+
+func (d *versionedDecoder) blob(tok blizzval.Token) string {
+	if tok.Kind != blizzval.KindBlob {
+		panic(mismatch(tok, "blob"))
+	}
+	return string(tok.Bytes)
+}
+
+// fourCC decodes a four-character code, which is encoded as a u32.
+func (d *versionedDecoder) fourCC(tok blizzval.Token) FourCC {
+	if tok.Kind != blizzval.KindInt || tok.Tag != 0x7 {
+		panic(mismatch(tok, "fourcc"))
+	}
+	var f FourCC
+	binary.LittleEndian.PutUint32(f[:], uint32(tok.Int))
+	return f
+}
+
+func (d *versionedDecoder) bitArray(tok blizzval.Token) BitArray {
+	if tok.Kind != blizzval.KindBitArray {
+		panic(mismatch(tok, "bit array"))
+	}
+	return bitArrayFromBytes(tok.Len, tok.Bytes)
+}
+
+// array checks that tok starts an array, returning its length.  The
+// caller decodes that many elements and then calls end.
+func (d *versionedDecoder) array(tok blizzval.Token) int {
+	if tok.Kind != blizzval.KindStartArray {
+		panic(mismatch(tok, "array"))
+	}
+	return tok.Len
+}
+
+// end reads the end of an array.
+func (d *versionedDecoder) end() {
+	if tok := d.token(); tok.Kind != blizzval.KindEndArray {
+		panic(mismatch(tok, "end of array"))
+	}
+}
+
+// structStart checks that tok starts a struct, whose fields are then
+// read with field.
+func (d *versionedDecoder) structStart(tok blizzval.Token) {
+	if tok.Kind != blizzval.KindStartMap {
+		panic(mismatch(tok, "struct"))
+	}
+}
+
+// field returns the first token of the next field of a struct, with
+// its tag in Key, or false at the end of the struct.
+func (d *versionedDecoder) field() (blizzval.Token, bool) {
+	tok := d.token()
+	return tok, tok.Kind != blizzval.KindEndMap
+}
+
+// choice checks that tok is a choice, returning its tag.  The caller
+// then decodes the chosen value, if it is not null.
+func (d *versionedDecoder) choice(tok blizzval.Token) int {
+	if tok.Kind != blizzval.KindChoice {
+		panic(mismatch(tok, "choice"))
+	}
+	return int(tok.Int)
+}
+
+// endChoice ends a choice whose variant is null, which has no value
+// to decode.
+func (d *versionedDecoder) endChoice() {
+	if err := d.d.EndChoice(); err != nil {
+		panic(err)
+	}
+}
+
+// skip skips the value begun by tok, for fields of unknown tags.  A
+// choice ended by endChoice has nothing left to skip.
+func (d *versionedDecoder) skip(tok blizzval.Token) {
+	switch tok.Kind {
+	case blizzval.KindStartArray, blizzval.KindStartMap, blizzval.KindChoice:
+		if err := d.d.Skip(); err != nil {
+			panic(err)
+		}
+	}
+}
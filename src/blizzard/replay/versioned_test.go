@@ -0,0 +1,76 @@
+package replay
+
+import (
+	"strings"
+	"testing"
+)
+
+func versionedGameLoopDelta(in string) (delta int32, err error) {
+	defer catchError(&err)
+	d := newVersionedDecoder(strings.NewReader(in))
+	return decoders34835{}.decodeVersionedGameLoopDeltaAuto(d, d.token()), nil
+}
+
+func TestVersionedChoice(t *testing.T) {
+	// Choice 1, m_uint14, holding the varint 300.
+	delta, err := versionedGameLoopDelta("\x03\x02\x09\xd8\x04")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delta != 300 {
+		t.Errorf("got %d, expected 300", delta)
+	}
+
+	for _, in := range []string{
+		"\x09\x02",     // not a choice
+		"\x03\x08\x09", // unknown choice tag
+		"\x03\x02",     // missing value
+	} {
+		if _, err := versionedGameLoopDelta(in); err == nil {
+			t.Errorf("%q: decoded without error", in)
+		}
+	}
+}
+
+func TestVersionedBitArray(t *testing.T) {
+	decode := func(in string) (b BitArray, err error) {
+		defer catchError(&err)
+		d := newVersionedDecoder(strings.NewReader(in))
+		return decoders34835{}.decodeVersionedUnknown52(d, d.token()), nil
+	}
+
+	// 10 bits, as the big-endian number 0x201.
+	b, err := decode("\x01\x14\x02\x01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Len != 10 || b.String() != "1000000001" {
+		t.Errorf("got %s, expected 1000000001", b)
+	}
+
+	for _, in := range []string{
+		"\x02\x02x",    // a blob
+		"\x01\x14\x02", // truncated
+	} {
+		if _, err := decode(in); err == nil {
+			t.Errorf("%q: decoded without error", in)
+		}
+	}
+}
+
+func TestVersionedNullChoice(t *testing.T) {
+	// A Delta whose RemoveMask is choice 0, None, which has no value,
+	// followed by its SubgroupIndex.
+	in := "\x05\x04" + "\x02\x03\x00" + "\x00\x09\x0a"
+	delta, err := func() (delta *Delta, err error) {
+		defer catchError(&err)
+		d := newVersionedDecoder(strings.NewReader(in))
+		return decoders34835{}.decodeVersionedDelta(d, d.token()), nil
+	}()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delta.RemoveMask != nil || delta.SubgroupIndex != 5 {
+		t.Errorf("got %+v, expected SubgroupIndex 5 and no RemoveMask", delta)
+	}
+}